@@ -0,0 +1,146 @@
+package goconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// sourceTagName is the struct tag naming the source a field is loaded from
+const sourceTagName = "source"
+
+// AnnotatedOptions defines a set of functional options for the annotated loader
+type AnnotatedOptions struct {
+	// DefaultSource is the source of fields that neither carry a source tag nor belong to a tagged struct
+	DefaultSource string
+}
+
+// AnnotatedOption defines a functional option for the annotated loader
+type AnnotatedOption func(*AnnotatedOptions)
+
+// WithDefaultSource loads fields without a source tag from the named source
+func WithDefaultSource(name string) AnnotatedOption {
+	return func(o *AnnotatedOptions) {
+		o.DefaultSource = name
+	}
+}
+
+// AnnotatedLoader builds a configuration field by field, taking each field from the source
+// named in its source tag. It is more granular than ParallelLoader, which merges whole results.
+type AnnotatedLoader[T any] struct {
+	sources map[string]ConfigLoaderContext[T]
+	options AnnotatedOptions
+	used    []string
+}
+
+// NewAnnotatedLoader creates a loader that reads the source tag of every field of T and copies
+// the field from the result of the named loader, e.g. source:"vault" for a password and
+// source:"env" for the rest. A tag on a struct field applies to all of its fields unless they
+// carry their own. Untagged fields come from the default source set with WithDefaultSource
+// and stay zero without one. Every tag must name one of sources.
+func NewAnnotatedLoader[T any](sources map[string]ConfigLoaderContext[T], opts ...AnnotatedOption) (*AnnotatedLoader[T], error) {
+	var options AnnotatedOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	used := make(map[string]bool)
+	if options.DefaultSource != "" {
+		used[options.DefaultSource] = true
+	}
+	collectSources(reflect.TypeFor[T](), used)
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		if _, ok := sources[name]; !ok {
+			return nil, fmt.Errorf("error creating loader: unknown source %q", name)
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return &AnnotatedLoader[T]{sources: sources, options: options, used: names}, nil
+}
+
+// Load loads the configuration from the annotated sources
+func (l *AnnotatedLoader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext runs every source referenced by T once, in name order, and assembles the
+// configuration from their results. The first failure is returned.
+func (l *AnnotatedLoader[T]) LoadContext(ctx context.Context) (*T, error) {
+	results := make(map[string]reflect.Value, len(l.used))
+	for _, name := range l.used {
+		cfg, err := l.sources[name].LoadContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("annotated loader source %q failed: %w", name, err)
+		}
+
+		if cfg == nil {
+			return nil, fmt.Errorf("annotated loader source %q returned a nil config", name)
+		}
+
+		results[name] = reflect.ValueOf(cfg).Elem()
+	}
+
+	cfg := new(T)
+	assembleFields(reflect.ValueOf(cfg).Elem(), results, l.options.DefaultSource, nil)
+
+	return cfg, nil
+}
+
+// collectSources adds the source names tagged anywhere in the struct type t to used
+func collectSources(t reflect.Type, used map[string]bool) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if name, ok := field.Tag.Lookup(sourceTagName); ok {
+			used[name] = true
+		}
+
+		collectSources(field.Type, used)
+	}
+}
+
+// hasSourceTags reports whether any field of the struct type t, at any depth, has a source tag
+func hasSourceTags(t reflect.Type) bool {
+	used := make(map[string]bool)
+	collectSources(t, used)
+	return len(used) > 0
+}
+
+// assembleFields copies every field of dst from the result of its source, inheriting source
+// from the enclosing struct. index is the path of dst within the results.
+func assembleFields(dst reflect.Value, results map[string]reflect.Value, source string, index []int) {
+	for i := range dst.NumField() {
+		field := dst.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldSource := source
+		if name, ok := field.Tag.Lookup(sourceTagName); ok {
+			fieldSource = name
+		}
+
+		fieldIndex := append(slices.Clone(index), i)
+
+		if field.Type.Kind() == reflect.Struct && hasSourceTags(field.Type) {
+			assembleFields(dst.Field(i), results, fieldSource, fieldIndex)
+			continue
+		}
+
+		if src, ok := results[fieldSource]; ok {
+			dst.Field(i).Set(src.FieldByIndex(fieldIndex))
+		}
+	}
+}
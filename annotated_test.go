@@ -0,0 +1,118 @@
+package goconfig_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type annotatedConfig struct {
+	Port     int `source:"env"`
+	LogLevel string
+
+	Database struct {
+		Host     string
+		Password string `source:"vault"`
+	} `source:"env"`
+
+	Timeout time.Duration
+}
+
+func annotatedSource(port int, logLevel, host, password string, timeout time.Duration) *goconfig.StaticLoader[annotatedConfig] {
+	cfg := &annotatedConfig{Port: port, LogLevel: logLevel, Timeout: timeout}
+	cfg.Database.Host = host
+	cfg.Database.Password = password
+	return goconfig.NewStaticLoader(cfg)
+}
+
+func TestAnnotatedLoader(t *testing.T) {
+	sources := map[string]goconfig.ConfigLoaderContext[annotatedConfig]{
+		"env":   annotatedSource(8080, "debug", "db.internal", "env-password", time.Second),
+		"vault": annotatedSource(9090, "error", "vault.internal", "s3cr3t", time.Minute),
+	}
+
+	loader, err := goconfig.NewAnnotatedLoader(sources)
+	if err != nil {
+		t.Fatalf("failed to create annotated loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Port != 8080 {
+		t.Errorf("Port: expected %d from env, got %d", 8080, cfg.Port)
+	}
+	if cfg.Database.Host != "db.internal" {
+		t.Errorf("Database.Host: expected %q inherited from env, got %q", "db.internal", cfg.Database.Host)
+	}
+	if cfg.Database.Password != "s3cr3t" {
+		t.Errorf("Database.Password: expected %q from vault, got %q", "s3cr3t", cfg.Database.Password)
+	}
+	if cfg.LogLevel != "" || cfg.Timeout != 0 {
+		t.Errorf("expected untagged fields to stay zero without a default source, got %q and %v", cfg.LogLevel, cfg.Timeout)
+	}
+}
+
+func TestAnnotatedLoaderWithDefaultSource(t *testing.T) {
+	sources := map[string]goconfig.ConfigLoaderContext[annotatedConfig]{
+		"env":   annotatedSource(8080, "debug", "db.internal", "", time.Second),
+		"vault": annotatedSource(0, "", "", "s3cr3t", 0),
+		"file":  annotatedSource(0, "info", "", "", 5*time.Second),
+	}
+
+	loader, err := goconfig.NewAnnotatedLoader(sources, goconfig.WithDefaultSource("file"))
+	if err != nil {
+		t.Fatalf("failed to create annotated loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.LogLevel != "info" || cfg.Timeout != 5*time.Second {
+		t.Errorf("expected untagged fields from file, got %q and %v", cfg.LogLevel, cfg.Timeout)
+	}
+	if cfg.Port != 8080 || cfg.Database.Password != "s3cr3t" {
+		t.Errorf("expected tagged fields from their sources, got %+v", cfg)
+	}
+}
+
+func TestAnnotatedLoaderErrors(t *testing.T) {
+	t.Run("Unknown source", func(t *testing.T) {
+		sources := map[string]goconfig.ConfigLoaderContext[annotatedConfig]{
+			"env": annotatedSource(8080, "", "", "", 0),
+		}
+
+		_, err := goconfig.NewAnnotatedLoader(sources)
+		if err == nil || !strings.Contains(err.Error(), `unknown source "vault"`) {
+			t.Errorf("expected unknown source error, got %v", err)
+		}
+	})
+
+	t.Run("Source failure", func(t *testing.T) {
+		sources := map[string]goconfig.ConfigLoaderContext[annotatedConfig]{
+			"env":   annotatedSource(8080, "", "", "", 0),
+			"vault": annotatedSource(0, "", "", "s3cr3t", 0),
+		}
+
+		loader, err := goconfig.NewAnnotatedLoader(sources)
+		if err != nil {
+			t.Fatalf("failed to create annotated loader: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = loader.LoadContext(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
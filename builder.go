@@ -0,0 +1,59 @@
+package goconfig
+
+import "fmt"
+
+// ConfigBuilder loads several independent configurations, possibly of different types,
+// with unified error handling. Register each configuration with AddConfig, then call Build.
+type ConfigBuilder struct {
+	steps []configStep
+}
+
+// configStep loads one configuration and returns a function that stores it in its destination
+type configStep struct {
+	name string
+	load func() (store func(), err error)
+}
+
+// NewConfigBuilder creates an empty builder
+func NewConfigBuilder() *ConfigBuilder {
+	return &ConfigBuilder{}
+}
+
+// AddConfig registers a configuration named name that Build loads with loader into *dst.
+// It is a function rather than a method because methods cannot have type parameters.
+func AddConfig[T any](b *ConfigBuilder, name string, loader ConfigLoader[T], dst **T) *ConfigBuilder {
+	b.steps = append(b.steps, configStep{
+		name: name,
+		load: func() (func(), error) {
+			cfg, err := loader.Load()
+			if err != nil {
+				return nil, err
+			}
+
+			return func() { *dst = cfg }, nil
+		},
+	})
+
+	return b
+}
+
+// Build loads every registered configuration in registration order and stops at the first
+// failure, returning it wrapped with the name of the configuration. Destinations are only
+// assigned once all configurations have loaded, so a failed Build leaves them untouched.
+func (b *ConfigBuilder) Build() error {
+	stores := make([]func(), 0, len(b.steps))
+	for _, step := range b.steps {
+		store, err := step.load()
+		if err != nil {
+			return fmt.Errorf("config %q failed to load: %w", step.name, err)
+		}
+
+		stores = append(stores, store)
+	}
+
+	for _, store := range stores {
+		store()
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+// Package builder assembles a layered configuration loader with chained calls instead of
+// nesting loaders by hand:
+//
+//	cfg, err := builder.New[Config]().
+//		WithDefaults(&Config{Port: 8080}).
+//		WithFile("config.yaml").
+//		WithEnv().
+//		WithValidation().
+//		Load()
+//
+// Layers are combined with goconfig.NewParallelLoader, so non-zero values of later layers
+// override earlier ones: defaults come first, then files in the order they are added, then
+// the environment. Fields with an envDefault tag are always set by the env layer and so win
+// over files; put such defaults in WithDefaults instead.
+package builder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+	"github.com/nikita-shtimenko/goconfig/loader/hcl"
+	"github.com/nikita-shtimenko/goconfig/loader/json"
+	"github.com/nikita-shtimenko/goconfig/loader/toml"
+	"github.com/nikita-shtimenko/goconfig/loader/xml"
+	"github.com/nikita-shtimenko/goconfig/loader/yaml"
+)
+
+// Builder collects the layers of a configuration. The zero value is not usable; create one
+// with New. Errors from the With methods are kept and returned by Build.
+type Builder[T any] struct {
+	defaults *T
+	files    []goconfig.ConfigLoaderContext[T]
+	names    []string
+	env      []env.Option
+	withEnv  bool
+	validate bool
+	err      error
+}
+
+// New creates an empty builder
+func New[T any]() *Builder[T] {
+	return &Builder[T]{}
+}
+
+// WithDefaults sets the lowest-precedence layer. cfg is copied when Build is called.
+func (b *Builder[T]) WithDefaults(cfg *T) *Builder[T] {
+	b.defaults = cfg
+	return b
+}
+
+// WithFile adds a file layer, choosing the loader by extension: .json, .yaml or .yml, .toml,
+// .hcl and .xml are supported. Other extensions make Build fail with
+// goconfig.ErrUnsupportedFormat. A missing file is an error.
+func (b *Builder[T]) WithFile(path string) *Builder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	loader, err := fileLoader[T](path)
+	if err != nil {
+		b.err = fmt.Errorf("error adding file %s: %w", path, err)
+		return b
+	}
+
+	b.files = append(b.files, loader)
+	b.names = append(b.names, path)
+	return b
+}
+
+// WithEnv adds the process environment as the highest-precedence layer, read on every Load.
+// opts configure the env loader as for env.NewLoader, e.g. env.WithEnvPrefix; env.WithEnvironment
+// replaces the process environment.
+func (b *Builder[T]) WithEnv(opts ...env.Option) *Builder[T] {
+	b.withEnv = true
+	b.env = opts
+	return b
+}
+
+// WithValidation makes Load call Validate on the result if *T implements goconfig.Validator
+func (b *Builder[T]) WithValidation() *Builder[T] {
+	b.validate = true
+	return b
+}
+
+// Build composes the layers into a loader, or returns the first error from a With method
+func (b *Builder[T]) Build() (*Loader[T], error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	loaders := []goconfig.ConfigLoaderContext[T]{goconfig.NewStaticLoader(b.defaults)}
+	loaders = append(loaders, b.files...)
+	names := append([]string{"defaults"}, b.names...)
+
+	if b.withEnv {
+		// Check the options now rather than on the first Load
+		opts := b.env
+		if _, err := env.NewLoader[T](nil, append([]env.Option{env.WithEnvironment(map[string]string{})}, opts...)...); err != nil {
+			return nil, err
+		}
+
+		loaders = append(loaders, envLoader[T](opts))
+		names = append(names, "env")
+	}
+
+	return &Loader[T]{
+		inner:    goconfig.NewParallelLoader(loaders...),
+		names:    names,
+		validate: b.validate,
+	}, nil
+}
+
+// Load builds the loader and loads the configuration in one call
+func (b *Builder[T]) Load() (*T, error) {
+	loader, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.Load()
+}
+
+// fileLoader creates the loader for path based on its extension
+func fileLoader[T any](path string) (goconfig.ConfigLoaderContext[T], error) {
+	files := []string{path}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return json.NewLoader[T](files)
+	case ".yaml", ".yml":
+		return yaml.NewLoader[T](files)
+	case ".toml":
+		return toml.NewLoader[T](files)
+	case ".hcl":
+		return hcl.NewLoader[T](files)
+	case ".xml":
+		return xml.NewLoader[T](files)
+	default:
+		return nil, fmt.Errorf("%w %q", goconfig.ErrUnsupportedFormat, ext)
+	}
+}
+
+// envLoader loads the process environment with an env.Loader created on each call, since
+// env.NewLoader without files only accepts an explicit environment
+type envLoader[T any] []env.Option
+
+// Load loads the process environment
+func (opts envLoader[T]) Load() (*T, error) {
+	return opts.LoadContext(context.Background())
+}
+
+// LoadContext snapshots the process environment and loads it with the configured options
+func (opts envLoader[T]) LoadContext(ctx context.Context) (*T, error) {
+	environ := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			environ[key] = value
+		}
+	}
+
+	loader, err := env.NewLoader[T](nil, append([]env.Option{env.WithEnvironment(environ)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.LoadContext(ctx)
+}
+
+// Loader is the composed loader returned by Builder.Build
+type Loader[T any] struct {
+	inner    *goconfig.ParallelLoader[T]
+	names    []string
+	validate bool
+}
+
+// Load loads all layers and merges them
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext loads all layers concurrently and merges them, validating the result if the
+// builder was configured WithValidation
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	cfg, err := l.inner.LoadContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.check(cfg)
+}
+
+// LoadWithProvenance loads the configuration like LoadContext and also reports which layer
+// supplied each value, recorded while the layers are merged. The result maps the dotted path
+// of every field taken from a layer, e.g. "Database.Host", to the layer's name: "defaults",
+// the path given to WithFile, or "env". Fields no layer set are absent.
+func (l *Loader[T]) LoadWithProvenance(ctx context.Context) (*T, map[string]string, error) {
+	cfg, indices, err := l.inner.LoadWithProvenance(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg, err = l.check(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	provenance := make(map[string]string, len(indices))
+	for path, i := range indices {
+		provenance[path] = l.names[i]
+	}
+
+	return cfg, provenance, nil
+}
+
+// check validates cfg if the builder was configured WithValidation
+func (l *Loader[T]) check(cfg *T) (*T, error) {
+	if validator, ok := any(cfg).(goconfig.Validator); ok && l.validate {
+		if err := validator.Validate(); err != nil {
+			return nil, fmt.Errorf("config is invalid: %w", err)
+		}
+	}
+
+	return cfg, nil
+}
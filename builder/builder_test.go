@@ -0,0 +1,143 @@
+package builder_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/builder"
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type ServiceConfig struct {
+	Name     string `json:"name" yaml:"name" env:"NAME"`
+	Host     string `json:"host" yaml:"host" env:"HOST"`
+	Port     int    `json:"port" yaml:"port" env:"PORT"`
+	LogLevel string `json:"log_level" yaml:"log_level" env:"LOG_LEVEL"`
+}
+
+func (c *ServiceConfig) Validate() error {
+	if c.Port <= 0 {
+		return errors.New("port must be positive")
+	}
+
+	return nil
+}
+
+func createTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+
+	return path
+}
+
+func TestBuilderPrecedence(t *testing.T) {
+	base := createTempFile(t, "config.json", `{"name": "billing", "host": "base.internal", "port": 8080}`)
+	local := createTempFile(t, "config.local.yaml", "host: local.internal\n")
+
+	cfg, err := builder.New[ServiceConfig]().
+		WithDefaults(&ServiceConfig{Name: "default", Port: 80, LogLevel: "info"}).
+		WithFile(base).
+		WithFile(local).
+		WithEnv(env.WithEnvironment(map[string]string{"PORT": "9090"})).
+		WithValidation().
+		Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	want := ServiceConfig{
+		Name:     "billing",        // file overrides default
+		Host:     "local.internal", // later file overrides earlier file
+		Port:     9090,             // env overrides files
+		LogLevel: "info",           // default kept where no layer sets it
+	}
+	if *cfg != want {
+		t.Errorf("expected %+v, got %+v", want, *cfg)
+	}
+}
+
+func TestBuilderLoadWithProvenance(t *testing.T) {
+	base := createTempFile(t, "config.json", `{"name": "billing", "host": "base.internal"}`)
+
+	loader, err := builder.New[ServiceConfig]().
+		WithDefaults(&ServiceConfig{Name: "default", LogLevel: "info"}).
+		WithFile(base).
+		WithEnv(env.WithEnvironment(map[string]string{"PORT": "9090"})).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build loader: %v", err)
+	}
+
+	cfg, provenance, err := loader.LoadWithProvenance(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" || cfg.Port != 9090 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	want := map[string]string{
+		"Name":     base,
+		"Host":     base,
+		"Port":     "env",
+		"LogLevel": "defaults",
+	}
+	if !reflect.DeepEqual(provenance, want) {
+		t.Errorf("expected provenance %v, got %v", want, provenance)
+	}
+}
+
+func TestBuilderProcessEnvironment(t *testing.T) {
+	t.Setenv("APP_PORT", "7070")
+
+	loader, err := builder.New[ServiceConfig]().
+		WithDefaults(&ServiceConfig{Port: 80}).
+		WithEnv(env.WithEnvPrefix("APP_")).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Port != 7070 {
+		t.Errorf("expected port from the process environment, got %d", cfg.Port)
+	}
+}
+
+func TestBuilderValidation(t *testing.T) {
+	b := builder.New[ServiceConfig]().WithDefaults(&ServiceConfig{Name: "billing"})
+
+	if _, err := b.Load(); err != nil {
+		t.Fatalf("expected no validation without WithValidation, got %v", err)
+	}
+
+	if _, err := b.WithValidation().Load(); err == nil {
+		t.Fatal("expected the invalid port to fail validation")
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	_, err := builder.New[ServiceConfig]().WithFile("config.conf").Build()
+	if !errors.Is(err, goconfig.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	if _, err := builder.New[ServiceConfig]().WithFile(missing).Load(); !errors.Is(err, goconfig.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package goconfig_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type httpConfig struct {
+	Port int
+}
+
+type dbConfig struct {
+	Host string
+}
+
+type cacheConfig struct {
+	TTL int
+}
+
+// failingLoader is a loader that always fails with err
+type failingLoader[T any] struct {
+	err error
+}
+
+func (l failingLoader[T]) Load() (*T, error) {
+	return nil, l.err
+}
+
+func TestConfigBuilder(t *testing.T) {
+	var (
+		httpCfg  *httpConfig
+		dbCfg    *dbConfig
+		cacheCfg *cacheConfig
+	)
+
+	b := goconfig.NewConfigBuilder()
+	goconfig.AddConfig(b, "http", goconfig.NewStaticLoader(&httpConfig{Port: 8080}), &httpCfg)
+	goconfig.AddConfig(b, "db", goconfig.NewStaticLoader(&dbConfig{Host: "db.internal"}), &dbCfg)
+	goconfig.AddConfig(b, "cache", goconfig.NewStaticLoader(&cacheConfig{TTL: 60}), &cacheCfg)
+
+	if err := b.Build(); err != nil {
+		t.Fatalf("unexpected error building configs: %v", err)
+	}
+
+	if httpCfg == nil || httpCfg.Port != 8080 {
+		t.Errorf("expected http config with port 8080, got %+v", httpCfg)
+	}
+	if dbCfg == nil || dbCfg.Host != "db.internal" {
+		t.Errorf("expected db config with host db.internal, got %+v", dbCfg)
+	}
+	if cacheCfg == nil || cacheCfg.TTL != 60 {
+		t.Errorf("expected cache config with TTL 60, got %+v", cacheCfg)
+	}
+}
+
+func TestConfigBuilderFailure(t *testing.T) {
+	errUnreachable := errors.New("connection refused")
+
+	var (
+		httpCfg  *httpConfig
+		dbCfg    *dbConfig
+		cacheCfg *cacheConfig
+	)
+
+	b := goconfig.NewConfigBuilder()
+	goconfig.AddConfig(b, "http", goconfig.NewStaticLoader(&httpConfig{Port: 8080}), &httpCfg)
+	goconfig.AddConfig[dbConfig](b, "db", failingLoader[dbConfig]{err: errUnreachable}, &dbCfg)
+	goconfig.AddConfig(b, "cache", goconfig.NewStaticLoader(&cacheConfig{TTL: 60}), &cacheCfg)
+
+	err := b.Build()
+	if !errors.Is(err, errUnreachable) {
+		t.Fatalf("expected load failure, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), `config "db"`) {
+		t.Errorf("expected error to identify the db config, got %v", err)
+	}
+
+	if httpCfg != nil || dbCfg != nil || cacheCfg != nil {
+		t.Errorf("expected destinations to stay nil after a failed Build, got %+v %+v %+v", httpCfg, dbCfg, cacheCfg)
+	}
+}
@@ -0,0 +1,115 @@
+package goconfig
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CachingOptions defines a set of functional options for the caching loader
+type CachingOptions struct {
+	// OnRefreshError is called when a refresh fails and a stale value is served instead
+	OnRefreshError func(error)
+}
+
+// CachingOption defines a functional option for the caching loader
+type CachingOption func(*CachingOptions)
+
+// WithRefreshErrorHandler registers a callback for refresh errors that are hidden by serving a stale value
+func WithRefreshErrorHandler(fn func(error)) CachingOption {
+	return func(opts *CachingOptions) {
+		opts.OnRefreshError = fn
+	}
+}
+
+// CachingLoader wraps a loader and caches its last successful result for a fixed TTL
+type CachingLoader[T any] struct {
+	inner   ConfigLoaderContext[T]
+	ttl     time.Duration
+	options CachingOptions
+
+	mu       sync.Mutex
+	cfg      *T
+	loadedAt time.Time
+	inflight *cachingCall[T]
+}
+
+// cachingCall tracks a single in-flight load shared by concurrent callers
+type cachingCall[T any] struct {
+	done chan struct{}
+	cfg  *T
+	err  error
+}
+
+// NewCachingLoader creates a loader that calls inner at most once per ttl.
+// Concurrent calls during a refresh share a single in-flight load. When a refresh fails
+// and a previous value exists, the stale value is returned and the error is passed to
+// the handler set with WithRefreshErrorHandler.
+func NewCachingLoader[T any](inner ConfigLoaderContext[T], ttl time.Duration, opts ...CachingOption) *CachingLoader[T] {
+	loader := &CachingLoader[T]{
+		inner: inner,
+		ttl:   ttl,
+	}
+
+	for _, opt := range opts {
+		opt(&loader.options)
+	}
+
+	return loader
+}
+
+// Load returns the cached configuration, refreshing it if the TTL has elapsed
+func (l *CachingLoader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext returns the cached configuration, refreshing it if the TTL has elapsed
+func (l *CachingLoader[T]) LoadContext(ctx context.Context) (*T, error) {
+	l.mu.Lock()
+	if l.cfg != nil && time.Since(l.loadedAt) < l.ttl {
+		cfg := l.cfg
+		l.mu.Unlock()
+		return cfg, nil
+	}
+
+	call := l.inflight
+	if call == nil {
+		call = &cachingCall[T]{done: make(chan struct{})}
+		l.inflight = call
+		go l.refresh(context.WithoutCancel(ctx), call)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.cfg, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// refresh performs the shared load and publishes its result to every waiter
+func (l *CachingLoader[T]) refresh(ctx context.Context, call *cachingCall[T]) {
+	cfg, err := l.inner.LoadContext(ctx)
+
+	l.mu.Lock()
+	stale := err != nil && l.cfg != nil
+	switch {
+	case err == nil:
+		l.cfg = cfg
+		l.loadedAt = time.Now()
+		call.cfg = cfg
+	case stale:
+		call.cfg = l.cfg
+	default:
+		call.err = err
+	}
+	l.inflight = nil
+	l.mu.Unlock()
+
+	if stale && l.options.OnRefreshError != nil {
+		l.options.OnRefreshError(err)
+	}
+
+	close(call.done)
+}
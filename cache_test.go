@@ -0,0 +1,123 @@
+package goconfig_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type sampleConfig struct {
+	AppName string
+	Port    int
+}
+
+// fakeLoader is a ConfigLoaderContext whose results are scripted per call
+type fakeLoader struct {
+	calls atomic.Int32
+	delay time.Duration
+	load  func(call int) (*sampleConfig, error)
+}
+
+func (f *fakeLoader) Load() (*sampleConfig, error) {
+	return f.LoadContext(context.Background())
+}
+
+func (f *fakeLoader) LoadContext(ctx context.Context) (*sampleConfig, error) {
+	call := int(f.calls.Add(1))
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return f.load(call)
+}
+
+func TestCachingLoaderSharesConcurrentLoads(t *testing.T) {
+	inner := &fakeLoader{
+		delay: 20 * time.Millisecond,
+		load: func(int) (*sampleConfig, error) {
+			return &sampleConfig{AppName: "cached", Port: 8080}, nil
+		},
+	}
+
+	loader := goconfig.NewCachingLoader[sampleConfig](inner, time.Minute)
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cfg, err := loader.LoadContext(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if cfg.AppName != "cached" {
+				t.Errorf("AppName: expected %q, got %q", "cached", cfg.AppName)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Errorf("expected inner loader to be called once within the TTL, got %d", calls)
+	}
+}
+
+func TestCachingLoaderServesStaleOnRefreshError(t *testing.T) {
+	errRefresh := errors.New("refresh failed")
+	inner := &fakeLoader{
+		load: func(call int) (*sampleConfig, error) {
+			if call > 1 {
+				return nil, errRefresh
+			}
+			return &sampleConfig{AppName: "first"}, nil
+		},
+	}
+
+	var reported error
+	loader := goconfig.NewCachingLoader[sampleConfig](inner, time.Millisecond,
+		goconfig.WithRefreshErrorHandler(func(err error) { reported = err }),
+	)
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("expected stale value without error, got %v", err)
+	}
+	if cfg.AppName != "first" {
+		t.Errorf("AppName: expected stale %q, got %q", "first", cfg.AppName)
+	}
+	if !errors.Is(reported, errRefresh) {
+		t.Errorf("expected refresh error to be reported, got %v", reported)
+	}
+}
+
+func TestCachingLoaderReturnsErrorWithoutCache(t *testing.T) {
+	errLoad := errors.New("load failed")
+	inner := &fakeLoader{
+		load: func(int) (*sampleConfig, error) { return nil, errLoad },
+	}
+
+	loader := goconfig.NewCachingLoader[sampleConfig](inner, time.Minute)
+	if _, err := loader.Load(); !errors.Is(err, errLoad) {
+		t.Fatalf("expected load error, got %v", err)
+	}
+}
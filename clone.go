@@ -0,0 +1,110 @@
+package goconfig
+
+import "reflect"
+
+// Clone returns a deep copy of cfg, duplicating slices, maps, pointers and nested structs
+// so that mutating the copy never affects the original. Structs with unexported fields
+// (such as time.Time) are copied by value, and funcs and channels are shared.
+// Clone returns nil for a nil cfg.
+func Clone[T any](cfg *T) *T {
+	if cfg == nil {
+		return nil
+	}
+
+	c := cloner{visited: make(map[visitKey]reflect.Value)}
+
+	cloned := new(T)
+	reflect.ValueOf(cloned).Elem().Set(c.clone(reflect.ValueOf(cfg).Elem()))
+
+	return cloned
+}
+
+// cloner deep-copies values, remembering visited pointers so shared and cyclic references
+// are preserved in the copy
+type cloner struct {
+	visited map[visitKey]reflect.Value
+}
+
+// visitKey identifies a pointer by type and address, since a struct and its first field share an address
+type visitKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+func (c cloner) clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		return c.clonePointer(v)
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		cloned := reflect.New(v.Type()).Elem()
+		cloned.Set(c.clone(v.Elem()))
+		return cloned
+	case reflect.Struct:
+		return c.cloneStruct(v)
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		cloned := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			cloned.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return cloned
+	case reflect.Array:
+		cloned := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			cloned.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return cloned
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		cloned := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cloned.SetMapIndex(c.clone(iter.Key()), c.clone(iter.Value()))
+		}
+		return cloned
+	default:
+		return v
+	}
+}
+
+func (c cloner) clonePointer(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return v
+	}
+
+	key := visitKey{typ: v.Type(), ptr: v.Pointer()}
+	if cloned, ok := c.visited[key]; ok {
+		return cloned
+	}
+
+	cloned := reflect.New(v.Type().Elem())
+	c.visited[key] = cloned
+	cloned.Elem().Set(c.clone(v.Elem()))
+
+	return cloned
+}
+
+func (c cloner) cloneStruct(v reflect.Value) reflect.Value {
+	cloned := reflect.New(v.Type()).Elem()
+	cloned.Set(v)
+
+	for i := range v.NumField() {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+
+		cloned.Field(i).Set(c.clone(v.Field(i)))
+	}
+
+	return cloned
+}
@@ -0,0 +1,85 @@
+package goconfig_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type cloneConfig struct {
+	Name    string
+	Timeout time.Duration
+	Started time.Time
+	Hosts   []string
+	Labels  map[string]string
+	Limit   *int
+
+	Upstreams []struct {
+		Host string
+		Tags []string
+	}
+
+	Database *struct {
+		Host    string
+		Options map[string][]string
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	limit := 10
+	original := &cloneConfig{
+		Name:    "app",
+		Timeout: 5 * time.Second,
+		Started: time.Date(2025, 5, 22, 10, 0, 0, 0, time.UTC),
+		Hosts:   []string{"a", "b"},
+		Labels:  map[string]string{"team": "core"},
+		Limit:   &limit,
+		Upstreams: []struct {
+			Host string
+			Tags []string
+		}{{Host: "u1", Tags: []string{"primary"}}},
+		Database: &struct {
+			Host    string
+			Options map[string][]string
+		}{Host: "db", Options: map[string][]string{"sslmode": {"require"}}},
+	}
+
+	cloned := goconfig.Clone(original)
+	if !reflect.DeepEqual(cloned, original) {
+		t.Fatalf("expected clone to equal original\noriginal: %+v\nclone:    %+v", original, cloned)
+	}
+
+	cloned.Hosts[0] = "changed"
+	cloned.Labels["team"] = "changed"
+	*cloned.Limit = 99
+	cloned.Upstreams[0].Tags[0] = "changed"
+	cloned.Database.Host = "changed"
+	cloned.Database.Options["sslmode"][0] = "changed"
+
+	if original.Hosts[0] != "a" {
+		t.Errorf("Hosts: original mutated to %v", original.Hosts)
+	}
+	if original.Labels["team"] != "core" {
+		t.Errorf("Labels: original mutated to %v", original.Labels)
+	}
+	if *original.Limit != 10 {
+		t.Errorf("Limit: original mutated to %d", *original.Limit)
+	}
+	if original.Upstreams[0].Tags[0] != "primary" {
+		t.Errorf("Upstreams: original mutated to %v", original.Upstreams)
+	}
+	if original.Database.Host != "db" || original.Database.Options["sslmode"][0] != "require" {
+		t.Errorf("Database: original mutated to %+v", *original.Database)
+	}
+	if !cloned.Started.Equal(original.Started) || cloned.Timeout != original.Timeout {
+		t.Errorf("expected time values to be copied, got %v and %v", cloned.Started, cloned.Timeout)
+	}
+}
+
+func TestCloneNil(t *testing.T) {
+	if goconfig.Clone[cloneConfig](nil) != nil {
+		t.Error("expected nil clone of nil config")
+	}
+}
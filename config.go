@@ -1,13 +1,39 @@
 // Package goconfig provides a generic interface and constructor for loading typed configuration.
 package goconfig
 
+import (
+	"context"
+	"errors"
+)
+
+// ErrSourceNotFound indicates that a configuration source (file, object, key prefix, etc.)
+// could not be found. Every built-in loader exposes it as its own ErrSourceNotFound, so
+// errors.Is matches it whichever loader failed.
+var ErrSourceNotFound = errors.New("source not found")
+
 // ConfigLoader defines a generic interface for loading configuration
 // This is the strategy interface that different config loaders implement
 type ConfigLoader[T any] interface {
 	Load() (*T, error)
 }
 
-// NewConfig creates a configuration of type T using the provided loader
+// ConfigLoaderContext defines a configuration loader that honors context cancellation and deadlines
+// Load is expected to behave like LoadContext(context.Background())
+type ConfigLoaderContext[T any] interface {
+	ConfigLoader[T]
+	LoadContext(ctx context.Context) (*T, error)
+}
+
+// Unmarshaler is implemented by configuration types that take full control of their own loading.
+// Only the env loader supports it: it passes every visible environment variable, keyed by name,
+// to LoadFrom instead of populating the struct through reflection. Other loaders ignore it.
+type Unmarshaler interface {
+	LoadFrom(raw map[string]any) error
+}
+
+// NewConfig creates a configuration of type T using the provided loader.
+// The config and error are passed through unchanged, so loaders that return a partially
+// populated config on failure, such as the env loader on parse errors, expose it here too.
 func NewConfig[T any](loader ConfigLoader[T]) (*T, error) {
 	return loader.Load()
 }
@@ -0,0 +1,135 @@
+// Package configtest provides helpers for building configuration values in tests
+// without running a loader.
+package configtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+// ErrUnknownField indicates that an override path does not name an exported field.
+var ErrUnknownField = errors.New("unknown config field")
+
+// Build returns a deep copy of base with overrides applied. Override keys are dotted Go
+// field paths such as "Database.Host"; nil pointers to structs along a path are allocated.
+// Values must be assignable or convertible to the field type, so untyped constants such
+// as 8080 work for any integer field; a number that does not fit the field, such as 300 for
+// a uint8, is an error. Overrides are applied in sorted path order.
+func Build[T any](base T, overrides map[string]any) (*T, error) {
+	cfg := goconfig.Clone(&base)
+
+	paths := make([]string, 0, len(overrides))
+	for path := range overrides {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if err := set(reflect.ValueOf(cfg).Elem(), path, overrides[path]); err != nil {
+			return nil, fmt.Errorf("error applying override %s: %w", path, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// set assigns value to the field at the dotted path within root
+func set(root reflect.Value, path string, value any) error {
+	v := root
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("%w: %s is not a struct", ErrUnknownField, v.Type())
+		}
+
+		sf, ok := v.Type().FieldByName(name)
+		if !ok || !sf.IsExported() {
+			return fmt.Errorf("%w %q in %s", ErrUnknownField, name, v.Type())
+		}
+
+		v = v.FieldByIndex(sf.Index)
+	}
+
+	if value == nil {
+		v.SetZero()
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Type().AssignableTo(v.Type()):
+		v.Set(rv)
+	case rv.Type().ConvertibleTo(v.Type()) && sameKindClass(rv.Kind(), v.Kind()):
+		if overflows(rv, v.Type()) {
+			return fmt.Errorf("value %v overflows %s", value, v.Type())
+		}
+		v.Set(rv.Convert(v.Type()))
+	default:
+		return fmt.Errorf("cannot use %T as %s", value, v.Type())
+	}
+
+	return nil
+}
+
+// overflows reports whether converting the number rv to t would not preserve its value,
+// e.g. 300 to uint8 or -1 to uint
+func overflows(rv reflect.Value, t reflect.Type) bool {
+	switch kindClass(rv.Kind()) {
+	case "integer":
+		if rv.CanInt() {
+			i := rv.Int()
+			if t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64 {
+				return i < 0 || t.OverflowUint(uint64(i))
+			}
+			return t.OverflowInt(i)
+		}
+
+		u := rv.Uint()
+		if t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64 {
+			return t.OverflowUint(u)
+		}
+		return u > math.MaxInt64 || t.OverflowInt(int64(u))
+	case "float":
+		return t.OverflowFloat(rv.Float())
+	default:
+		return false
+	}
+}
+
+// sameKindClass reports whether a conversion between the kinds keeps the value's meaning,
+// ruling out surprises such as converting an int to a string
+func sameKindClass(a, b reflect.Kind) bool {
+	return kindClass(a) != "" && kindClass(a) == kindClass(b)
+}
+
+func kindClass(k reflect.Kind) string {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Slice:
+		return "slice"
+	case reflect.Map:
+		return "map"
+	default:
+		return ""
+	}
+}
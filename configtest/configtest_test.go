@@ -0,0 +1,90 @@
+package configtest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/configtest"
+)
+
+type Config struct {
+	AppName string
+	Port    int
+	Workers uint8
+	Timeout time.Duration
+	Hosts   []string
+
+	Database struct {
+		Host string
+		Port int
+	}
+
+	Cache *struct {
+		TTL time.Duration
+	}
+}
+
+func TestBuildScalarOverrides(t *testing.T) {
+	base := Config{AppName: "base", Port: 8080, Hosts: []string{"a"}}
+
+	cfg, err := configtest.Build(base, map[string]any{
+		"Port":    9090,
+		"Timeout": 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.AppName != "base" || cfg.Port != 9090 || cfg.Timeout != 5*time.Second {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	cfg.Hosts[0] = "changed"
+	if base.Hosts[0] != "a" {
+		t.Error("expected Build to copy the base config")
+	}
+}
+
+func TestBuildNestedOverrides(t *testing.T) {
+	cfg, err := configtest.Build(Config{}, map[string]any{
+		"Database.Host": "db.test",
+		"Database.Port": 5433,
+		"Cache.TTL":     time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Database.Host != "db.test" || cfg.Database.Port != 5433 {
+		t.Errorf("unexpected database config: %+v", cfg.Database)
+	}
+	if cfg.Cache == nil || cfg.Cache.TTL != time.Minute {
+		t.Errorf("expected nil Cache to be allocated with TTL, got %+v", cfg.Cache)
+	}
+}
+
+func TestBuildInvalidOverrides(t *testing.T) {
+	if _, err := configtest.Build(Config{}, map[string]any{"Database.Missing": 1}); !errors.Is(err, configtest.ErrUnknownField) {
+		t.Errorf("expected ErrUnknownField, got %v", err)
+	}
+
+	if _, err := configtest.Build(Config{}, map[string]any{"AppName": 42}); err == nil {
+		t.Error("expected type mismatch error, got nil")
+	}
+
+	for _, value := range []any{300, -1, uint64(1 << 63)} {
+		if _, err := configtest.Build(Config{}, map[string]any{"Workers": value}); err == nil {
+			t.Errorf("expected overflow error for %v, got nil", value)
+		}
+	}
+
+	if _, err := configtest.Build(Config{}, map[string]any{"Port": uint64(1 << 63)}); err == nil {
+		t.Error("expected overflow error for an int field, got nil")
+	}
+
+	cfg, err := configtest.Build(Config{}, map[string]any{"Workers": 255})
+	if err != nil || cfg.Workers != 255 {
+		t.Errorf("expected Workers 255, got %v (err %v)", cfg, err)
+	}
+}
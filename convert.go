@@ -0,0 +1,175 @@
+package goconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies a structured configuration format
+type Format string
+
+const (
+	// FormatJSON is JSON as handled by encoding/json
+	FormatJSON Format = "json"
+
+	// FormatYAML is YAML as handled by gopkg.in/yaml.v3
+	FormatYAML Format = "yaml"
+
+	// FormatTOML is TOML as handled by github.com/BurntSushi/toml
+	FormatTOML Format = "toml"
+)
+
+// ErrUnsupportedFormat indicates that a format is not one of the supported Format values.
+var ErrUnsupportedFormat = errors.New("unsupported config format")
+
+// Convert reads a configuration document in srcFormat and re-emits it in dstFormat,
+// preserving its structure. Integers stay integers across formats. TOML requires the
+// document to be a table at the top level and cannot represent null values.
+func Convert(src []byte, srcFormat, dstFormat Format) ([]byte, error) {
+	doc, err := decodeDocument(src, srcFormat)
+	if err != nil {
+		return nil, fmt.Errorf("error converting config: failed to decode %s: %w", srcFormat, err)
+	}
+
+	out, err := encodeDocument(doc, dstFormat)
+	if err != nil {
+		return nil, fmt.Errorf("error converting config: failed to encode %s: %w", dstFormat, err)
+	}
+
+	return out, nil
+}
+
+// Unmarshal decodes data in format into v, honoring the struct tags of that format: json tags
+// for FormatJSON, yaml tags for FormatYAML and toml tags for FormatTOML.
+func Unmarshal(data []byte, format Format, v any) error {
+	switch format {
+	case FormatJSON:
+		return json.Unmarshal(data, v)
+	case FormatYAML:
+		return yaml.Unmarshal(data, v)
+	case FormatTOML:
+		_, err := toml.Decode(string(data), v)
+		return err
+	default:
+		return fmt.Errorf("%w %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// decodeDocument decodes src into generic maps, slices and scalars
+func decodeDocument(src []byte, format Format) (any, error) {
+	var doc any
+
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(src))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(src, &doc); err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		table := make(map[string]any)
+		if _, err := toml.Decode(string(src), &table); err != nil {
+			return nil, err
+		}
+		doc = table
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedFormat, format)
+	}
+
+	return normalizeDocument(doc)
+}
+
+// encodeDocument encodes a generic document in format
+func encodeDocument(doc any, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		out, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(out, '\n'), nil
+	case FormatYAML:
+		return yaml.Marshal(doc)
+	case FormatTOML:
+		if _, ok := doc.(map[string]any); !ok {
+			return nil, errors.New("top-level value must be a table")
+		}
+
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(doc); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// normalizeDocument converts the decoder-specific types of doc into map[string]any, []any,
+// int64 and float64 so that every encoder sees the same representation
+func normalizeDocument(doc any) (any, error) {
+	switch v := doc.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			normalized, err := normalizeDocument(value)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = normalized
+		}
+		return out, nil
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			normalized, err := normalizeDocument(value)
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(key)] = normalized
+		}
+		return out, nil
+	case []map[string]any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			normalized, err := normalizeDocument(value)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = normalized
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			normalized, err := normalizeDocument(value)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = normalized
+		}
+		return out, nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case int:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,64 @@
+package goconfig_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+const convertSource = `{
+  "name": "billing",
+  "port": 8080,
+  "ratio": 0.75,
+  "debug": true,
+  "hosts": ["a.internal", "b.internal"],
+  "database": {
+    "host": "db.internal",
+    "pool": {"min": 2, "max": 10}
+  }
+}`
+
+func TestConvertRoundTrip(t *testing.T) {
+	formats := []goconfig.Format{goconfig.FormatJSON, goconfig.FormatYAML, goconfig.FormatTOML}
+
+	// Normalize the source through a JSON-to-JSON conversion to get a comparable baseline
+	want, err := goconfig.Convert([]byte(convertSource), goconfig.FormatJSON, goconfig.FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error normalizing source: %v", err)
+	}
+
+	for _, src := range formats {
+		for _, dst := range formats {
+			t.Run(fmt.Sprintf("%s to %s", src, dst), func(t *testing.T) {
+				in, err := goconfig.Convert(want, goconfig.FormatJSON, src)
+				if err != nil {
+					t.Fatalf("failed to produce %s input: %v", src, err)
+				}
+
+				converted, err := goconfig.Convert(in, src, dst)
+				if err != nil {
+					t.Fatalf("failed to convert %s to %s: %v", src, dst, err)
+				}
+
+				back, err := goconfig.Convert(converted, dst, goconfig.FormatJSON)
+				if err != nil {
+					t.Fatalf("failed to convert %s back to json: %v\n%s", dst, err, converted)
+				}
+
+				if !reflect.DeepEqual(back, want) {
+					t.Errorf("round trip mismatch\nexpected:\n%s\ngot:\n%s", want, back)
+				}
+			})
+		}
+	}
+}
+
+func TestConvertUnsupportedFormat(t *testing.T) {
+	_, err := goconfig.Convert([]byte(convertSource), goconfig.FormatJSON, goconfig.Format("hcl"))
+	if !errors.Is(err, goconfig.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package goconfig
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// secretMask replaces the values of secret fields in a FieldChange
+const secretMask = "******"
+
+// FieldChange describes a field whose value differs between two configurations
+type FieldChange struct {
+	// Field is the dotted Go path of the field, e.g. "Database.Host"
+	Field string
+
+	// Key is the fully prefixed environment key of the field
+	Key string
+
+	// Old and New hold the differing values; both are masked for fields tagged secret:"true"
+	Old any
+	New any
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s (%s): %v -> %v", c.Field, c.Key, c.Old, c.New)
+}
+
+// Diff compares the env-tagged fields of old and new, recursing into nested structs, and
+// returns a FieldChange for every field whose value differs, in field order. Values of fields
+// tagged secret:"true" are masked, so the result is safe to log. A nil argument or a nil
+// nested pointer is compared as zero values. Diff returns nil if nothing changed.
+func Diff[T any](old, new *T) []FieldChange {
+	var changes []FieldChange
+	(fields.Walker{}).Walk(reflect.TypeFor[T](), func(f fields.Field) {
+		oldValue := fieldValue(old, f)
+		newValue := fieldValue(new, f)
+		if reflect.DeepEqual(oldValue, newValue) {
+			return
+		}
+
+		if f.Secret() {
+			oldValue, newValue = secretMask, secretMask
+		}
+
+		changes = append(changes, FieldChange{
+			Field: f.Name,
+			Key:   f.Key,
+			Old:   oldValue,
+			New:   newValue,
+		})
+	})
+
+	return changes
+}
+
+// fieldValue returns the value of f within cfg, or the zero value of its type
+// if cfg or a pointer along the way is nil
+func fieldValue[T any](cfg *T, f fields.Field) any {
+	if cfg != nil {
+		if v, ok := fields.Value(reflect.ValueOf(cfg).Elem(), f.Index); ok {
+			return v.Interface()
+		}
+	}
+
+	return reflect.Zero(f.Struct.Type).Interface()
+}
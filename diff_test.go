@@ -0,0 +1,57 @@
+package goconfig_test
+
+import (
+	"reflect"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type diffConfig struct {
+	Port int `env:"PORT"`
+
+	Database *struct {
+		Host     string `env:"HOST"`
+		Password string `env:"PASSWORD" secret:"true"`
+	} `envPrefix:"DB_"`
+}
+
+func TestDiff(t *testing.T) {
+	old := &diffConfig{Port: 8080}
+	old.Database = &struct {
+		Host     string `env:"HOST"`
+		Password string `env:"PASSWORD" secret:"true"`
+	}{Host: "localhost", Password: "old-secret"}
+
+	new := &diffConfig{Port: 8080}
+	new.Database = &struct {
+		Host     string `env:"HOST"`
+		Password string `env:"PASSWORD" secret:"true"`
+	}{Host: "localhost", Password: "new-secret"}
+
+	want := []goconfig.FieldChange{
+		{Field: "Database.Password", Key: "DB_PASSWORD", Old: "******", New: "******"},
+	}
+
+	got := goconfig.Diff(old, new)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes mismatch\nexpected: %v\ngot:      %v", want, got)
+	}
+}
+
+func TestDiffNil(t *testing.T) {
+	cfg := &diffConfig{Port: 8080}
+
+	if changes := goconfig.Diff(cfg, cfg); changes != nil {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+
+	want := []goconfig.FieldChange{
+		{Field: "Port", Key: "PORT", Old: 0, New: 8080},
+	}
+
+	got := goconfig.Diff(nil, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes mismatch\nexpected: %v\ngot:      %v", want, got)
+	}
+}
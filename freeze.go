@@ -0,0 +1,19 @@
+package goconfig
+
+// Frozen holds a configuration that cannot be mutated through the wrapper.
+// Every Get returns a fresh deep copy, so code that modifies the returned value
+// never affects other readers.
+type Frozen[T any] struct {
+	cfg *T
+}
+
+// Freeze returns a Frozen wrapper around a deep copy of cfg. Later changes to cfg
+// are not visible through the wrapper.
+func Freeze[T any](cfg *T) *Frozen[T] {
+	return &Frozen[T]{cfg: Clone(cfg)}
+}
+
+// Get returns a deep copy of the frozen configuration, or nil if it was frozen from nil
+func (f *Frozen[T]) Get() *T {
+	return Clone(f.cfg)
+}
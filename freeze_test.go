@@ -0,0 +1,48 @@
+package goconfig_test
+
+import (
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type frozenConfig struct {
+	AppName string
+	Hosts   []string
+	Labels  map[string]string
+}
+
+func TestFrozenGetReturnsIndependentCopies(t *testing.T) {
+	original := &frozenConfig{
+		AppName: "frozen",
+		Hosts:   []string{"a.internal"},
+		Labels:  map[string]string{"team": "core"},
+	}
+
+	frozen := goconfig.Freeze(original)
+
+	// Mutating the source after freezing must not leak into the wrapper
+	original.Hosts[0] = "changed.internal"
+
+	first := frozen.Get()
+	first.AppName = "mutated"
+	first.Hosts = append(first.Hosts, "b.internal")
+	first.Labels["team"] = "other"
+
+	second := frozen.Get()
+	if second.AppName != "frozen" {
+		t.Errorf("expected AppName frozen, got %q", second.AppName)
+	}
+	if len(second.Hosts) != 1 || second.Hosts[0] != "a.internal" {
+		t.Errorf("expected Hosts [a.internal], got %v", second.Hosts)
+	}
+	if second.Labels["team"] != "core" {
+		t.Errorf("expected label team=core, got %q", second.Labels["team"])
+	}
+}
+
+func TestFreezeNil(t *testing.T) {
+	if got := goconfig.Freeze[frozenConfig](nil).Get(); got != nil {
+		t.Errorf("expected nil, got %+v", got)
+	}
+}
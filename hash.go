@@ -0,0 +1,23 @@
+package goconfig
+
+import "errors"
+
+// ErrInputHashNotSupported indicates that a loader cannot report a hash of its inputs.
+var ErrInputHashNotSupported = errors.New("loader does not support input hashing")
+
+// InputHasher is implemented by loaders that can compute a stable hash of their input sources
+type InputHasher interface {
+	InputHash() (string, error)
+}
+
+// InputHash returns a deterministic hash of all inputs the loader reads, such as file
+// contents and relevant environment variables. Identical inputs always produce the same
+// hash, so it can be used to key caches or detect configuration changes across restarts.
+func InputHash[T any](loader ConfigLoader[T]) (string, error) {
+	hasher, ok := loader.(InputHasher)
+	if !ok {
+		return "", ErrInputHashNotSupported
+	}
+
+	return hasher.InputHash()
+}
@@ -0,0 +1,15 @@
+package goconfig_test
+
+import (
+	"errors"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+func TestInputHashNotSupported(t *testing.T) {
+	_, err := goconfig.InputHash[sampleConfig](&fakeLoader{})
+	if !errors.Is(err, goconfig.ErrInputHashNotSupported) {
+		t.Fatalf("expected ErrInputHashNotSupported, got %v", err)
+	}
+}
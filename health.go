@@ -0,0 +1,31 @@
+package goconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Pinger is implemented by loaders that can check that their source is reachable, e.g. for
+// startup probes, without loading and parsing the configuration
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// CheckAll pings every loader that implements Pinger and returns the failures joined together,
+// each naming the index of its loader. Loaders that do not implement Pinger are skipped.
+func CheckAll[T any](ctx context.Context, loaders ...ConfigLoader[T]) error {
+	var errs []error
+	for i, loader := range loaders {
+		pinger, ok := loader.(Pinger)
+		if !ok {
+			continue
+		}
+
+		if err := pinger.Ping(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("loader %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
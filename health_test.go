@@ -0,0 +1,44 @@
+package goconfig_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+// pingLoader is a loader whose Ping returns err
+type pingLoader struct {
+	err error
+}
+
+func (l *pingLoader) Load() (*remoteConfig, error) {
+	return &remoteConfig{}, nil
+}
+
+func (l *pingLoader) Ping(context.Context) error {
+	return l.err
+}
+
+func TestCheckAll(t *testing.T) {
+	errUnreachable := errors.New("connection refused")
+
+	err := goconfig.CheckAll[remoteConfig](context.Background(),
+		&pingLoader{},
+		goconfig.NewStaticLoader(&remoteConfig{}),
+		&pingLoader{err: errUnreachable},
+	)
+	if !errors.Is(err, errUnreachable) {
+		t.Fatalf("expected ping failure, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "loader 2") {
+		t.Errorf("expected error to name the failing loader, got %v", err)
+	}
+
+	if err := goconfig.CheckAll[remoteConfig](context.Background(), &pingLoader{}, &pingLoader{}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package goconfig
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Holder stores a configuration that can be replaced and read concurrently, e.g. from a
+// ReloadManager subscriber while request handlers read it. The zero value is ready to use
+// and holds nil.
+type Holder[T any] struct {
+	cfg atomic.Pointer[T]
+}
+
+// Store replaces the held configuration. Readers see either the old or the new value, never a mix.
+func (h *Holder[T]) Store(cfg *T) {
+	h.cfg.Store(cfg)
+}
+
+// Load returns the held configuration, or nil if none was stored. The value is shared with
+// other readers and must not be modified; store a Clone instead.
+func (h *Holder[T]) Load() *T {
+	return h.cfg.Load()
+}
+
+// globals holds one *Holder[T] per configuration type
+var globals sync.Map
+
+// global returns the process-wide holder for T, creating it on first use
+func global[T any]() *Holder[T] {
+	holder, _ := globals.LoadOrStore(reflect.TypeFor[T](), new(Holder[T]))
+	return holder.(*Holder[T])
+}
+
+// SetGlobal stores cfg as the process-wide configuration of type T, so that code can read it
+// with GetGlobal instead of having it passed around. Each type has its own slot.
+func SetGlobal[T any](cfg *T) {
+	global[T]().Store(cfg)
+}
+
+// GetGlobal returns the process-wide configuration of type T, or nil if SetGlobal was not called
+func GetGlobal[T any]() *T {
+	return global[T]().Load()
+}
@@ -0,0 +1,100 @@
+package goconfig_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type holderConfig struct {
+	Version int
+	Name    string
+}
+
+func TestHolderConcurrentStoreAndLoad(t *testing.T) {
+	var holder goconfig.Holder[holderConfig]
+
+	if cfg := holder.Load(); cfg != nil {
+		t.Fatalf("expected zero Holder to hold nil, got %+v", cfg)
+	}
+
+	const writers, readers, iterations = 8, 8, 1000
+
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				version := w*iterations + i
+				holder.Store(&holderConfig{Version: version, Name: fmt.Sprint(version)})
+			}
+		}()
+	}
+
+	errs := make(chan error, readers)
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range iterations {
+				cfg := holder.Load()
+				if cfg != nil && cfg.Name != fmt.Sprint(cfg.Version) {
+					errs <- fmt.Errorf("observed a torn config: %+v", cfg)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if holder.Load() == nil {
+		t.Error("expected a config after concurrent stores, got nil")
+	}
+}
+
+func TestGlobal(t *testing.T) {
+	type serviceConfig struct {
+		Port int
+	}
+	type workerConfig struct {
+		Concurrency int
+	}
+
+	if cfg := goconfig.GetGlobal[serviceConfig](); cfg != nil {
+		t.Fatalf("expected nil before SetGlobal, got %+v", cfg)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			goconfig.SetGlobal(&serviceConfig{Port: 8000 + i})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = goconfig.GetGlobal[serviceConfig]()
+		}()
+	}
+	wg.Wait()
+
+	goconfig.SetGlobal(&serviceConfig{Port: 8080})
+	goconfig.SetGlobal(&workerConfig{Concurrency: 4})
+
+	if cfg := goconfig.GetGlobal[serviceConfig](); cfg == nil || cfg.Port != 8080 {
+		t.Errorf("expected service config with port 8080, got %+v", cfg)
+	}
+
+	if cfg := goconfig.GetGlobal[workerConfig](); cfg == nil || cfg.Concurrency != 4 {
+		t.Errorf("expected worker config with concurrency 4, got %+v", cfg)
+	}
+}
@@ -0,0 +1,76 @@
+package goconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrImmutableFieldChanged indicates that a reload would change a field tagged immutable:"true"
+var ErrImmutableFieldChanged = errors.New("immutable field changed")
+
+// checkImmutable returns an error wrapping ErrImmutableFieldChanged that lists the fields
+// tagged immutable:"true" whose values differ between old and new, or nil if there are none.
+// Nested structs and pointers to structs are compared field by field.
+func checkImmutable[T any](old, new *T) error {
+	if old == nil || new == nil {
+		return nil
+	}
+
+	var changed []string
+	compareImmutable(reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem(), "", make(map[reflect.Type]bool), &changed)
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrImmutableFieldChanged, strings.Join(changed, ", "))
+}
+
+// compareImmutable appends to changed the paths of the immutable fields that differ between
+// the structs old and new. inProgress guards against self-referential types.
+func compareImmutable(old, new reflect.Value, path string, inProgress map[reflect.Type]bool, changed *[]string) {
+	t := old.Type()
+	if t.Kind() != reflect.Struct || inProgress[t] {
+		return
+	}
+
+	inProgress[t] = true
+	defer delete(inProgress, t)
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := fieldPath(path, sf.Name)
+		o, n := old.Field(i), new.Field(i)
+
+		if sf.Tag.Get("immutable") == "true" {
+			if !reflect.DeepEqual(o.Interface(), n.Interface()) {
+				*changed = append(*changed, name)
+			}
+
+			continue
+		}
+
+		if o.Kind() == reflect.Pointer && o.Type().Elem().Kind() == reflect.Struct {
+			o, n = elemOrZero(o), elemOrZero(n)
+		}
+
+		if o.Kind() == reflect.Struct {
+			compareImmutable(o, n, name, inProgress, changed)
+		}
+	}
+}
+
+// elemOrZero returns the value v points to, or the zero value of its element type if v is nil
+func elemOrZero(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+
+	return v.Elem()
+}
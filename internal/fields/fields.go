@@ -0,0 +1,185 @@
+// Package fields walks configuration structs and resolves the environment keys
+// their fields map to. It mirrors the tag semantics of github.com/caarlos0/env
+// (env, envDefault and envPrefix) so that helpers built on top of it agree with
+// what the env loader actually reads.
+package fields
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	// DefaultTagName is the struct tag holding the environment key.
+	DefaultTagName = "env"
+
+	// DefaultPrefixTagName is the struct tag holding the prefix for nested structs.
+	DefaultPrefixTagName = "envPrefix"
+
+	// DefaultValueTagName is the struct tag holding the default value.
+	DefaultValueTagName = "envDefault"
+)
+
+// Field describes a single leaf field carrying an environment key
+type Field struct {
+	// Name is the dotted Go path of the field, e.g. "Database.Host"
+	Name string
+
+	// Key is the fully prefixed environment key
+	Key string
+
+	// Index is the index sequence used to reach the field from the root struct
+	Index []int
+
+	// Struct is the underlying reflect.StructField
+	Struct reflect.StructField
+
+	// Options holds the options following the key in the tag, e.g. "required"
+	Options []string
+
+	// Default holds the default value, if HasDefault is set
+	Default    string
+	HasDefault bool
+}
+
+// HasOption reports whether the field tag carries the given option
+func (f Field) HasOption(name string) bool {
+	for _, opt := range f.Options {
+		if opt == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Required reports whether the field is marked as required
+func (f Field) Required() bool {
+	return f.HasOption("required")
+}
+
+// Secret reports whether the field is tagged with secret:"true"
+func (f Field) Secret() bool {
+	return f.Struct.Tag.Get("secret") == "true"
+}
+
+// Walker resolves environment keys for struct types
+type Walker struct {
+	TagName       string
+	PrefixTagName string
+	Prefix        string
+}
+
+// Walk calls fn for every field of t (a struct or pointer to struct) that
+// carries an environment key, descending into nested structs
+func (w Walker) Walk(t reflect.Type, fn func(Field)) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	w.walk(t, w.Prefix, "", nil, map[reflect.Type]bool{}, fn)
+}
+
+// Fields returns every field of t that carries an environment key
+func (w Walker) Fields(t reflect.Type) []Field {
+	var result []Field
+	w.Walk(t, func(f Field) {
+		result = append(result, f)
+	})
+
+	return result
+}
+
+// walk visits the fields of t. inProgress holds the struct types being walked on the current
+// path, so that self-referential types such as a *Node field of Node are not descended into.
+func (w Walker) walk(t reflect.Type, prefix, path string, index []int, inProgress map[reflect.Type]bool, fn func(Field)) {
+	inProgress[t] = true
+	defer delete(inProgress, t)
+
+	tagName := w.TagName
+	if tagName == "" {
+		tagName = DefaultTagName
+	}
+
+	prefixTagName := w.PrefixTagName
+	if prefixTagName == "" {
+		prefixTagName = DefaultPrefixTagName
+	}
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), i)
+		name := sf.Name
+		if path != "" {
+			name = path + "." + sf.Name
+		}
+
+		key, opts := ParseTag(sf.Tag.Get(tagName))
+		if key == "-" {
+			continue
+		}
+
+		if key != "" {
+			def, hasDef := sf.Tag.Lookup(DefaultValueTagName)
+			fn(Field{
+				Name:       name,
+				Key:        prefix + key,
+				Index:      fieldIndex,
+				Struct:     sf,
+				Options:    opts,
+				Default:    def,
+				HasDefault: hasDef,
+			})
+
+			continue
+		}
+
+		if nested := structType(sf.Type); nested != nil && !inProgress[nested] {
+			w.walk(nested, prefix+sf.Tag.Get(prefixTagName), name, fieldIndex, inProgress, fn)
+		}
+	}
+}
+
+// ParseTag splits an env tag into its key and options
+func ParseTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+// Value returns the value of the field at index within root, following pointers.
+// It reports false when a nil pointer is encountered along the way.
+func Value(root reflect.Value, index []int) (reflect.Value, bool) {
+	v := root
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+
+	return v, true
+}
+
+// structType returns the struct type behind t, or nil if t is not a struct or pointer to struct
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return t
+}
@@ -0,0 +1,39 @@
+package env
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+)
+
+// sourceDefault is the audit source for values taken from an envDefault tag
+const sourceDefault = "default"
+
+// secretKeys returns the environment keys of the secret:"true" fields of T
+func (l *Loader[T]) secretKeys() map[string]bool {
+	keys := make(map[string]bool)
+	for _, f := range l.walker().Fields(reflect.TypeFor[T]()) {
+		if f.Secret() {
+			keys[f.Key] = true
+		}
+	}
+
+	return keys
+}
+
+// logSecretAccess writes an audit record for a secret key populated during Load
+func (l *Loader[T]) logSecretAccess(tr *tracer, key string, isDefault bool) {
+	if l.Options.SecretAccessLog == nil {
+		return
+	}
+
+	source := sourceDefault
+	if !isDefault {
+		source = tr.source(key)
+	}
+
+	l.Options.SecretAccessLog.LogAttrs(context.Background(), slog.LevelInfo, "secret accessed",
+		slog.String("key", key),
+		slog.String("source", source),
+	)
+}
@@ -0,0 +1,92 @@
+package env_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type AuditedConfig struct {
+	AppName  string `env:"APP_NAME"`
+	Password string `env:"DB_PASSWORD" secret:"true"`
+	APIKey   string `env:"API_KEY" secret:"true" envDefault:"default-key"`
+}
+
+func TestLoaderSecretAccessLog(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "DB_PASSWORD")
+
+	file := createTempEnvFile(t, "APP_NAME=audited\nDB_PASSWORD=hunter2\n")
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	loader, err := env.NewLoader[AuditedConfig]([]string{file}, env.WithSecretAccessLog(logger))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"hunter2", "default-key"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("audit log leaked secret value %q: %s", secret, output)
+		}
+	}
+
+	got := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var record struct {
+			Time   string `json:"time"`
+			Msg    string `json:"msg"`
+			Key    string `json:"key"`
+			Source string `json:"source"`
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log record %q: %v", line, err)
+		}
+
+		if record.Time == "" || record.Msg != "secret accessed" {
+			t.Errorf("unexpected log record: %s", line)
+		}
+
+		got[record.Key] = record.Source
+	}
+
+	want := map[string]string{"DB_PASSWORD": file, "API_KEY": "default"}
+	if len(got) != len(want) || got["DB_PASSWORD"] != want["DB_PASSWORD"] || got["API_KEY"] != want["API_KEY"] {
+		t.Errorf("expected one record per secret %v, got %v", want, got)
+	}
+}
+
+func TestLoaderSecretAccessLogUnsetSecret(t *testing.T) {
+	type Config struct {
+		AppName string `env:"APP_NAME"`
+		Token   string `env:"TOKEN" secret:"true"`
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	loader, err := env.NewLoader[Config](nil,
+		env.WithEnvironment(map[string]string{"APP_NAME": "audited"}),
+		env.WithSecretAccessLog(logger),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no audit record for an unset secret, got %s", buf.String())
+	}
+}
@@ -0,0 +1,44 @@
+package env_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestBytesLoader(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	loader, err := env.NewBytesLoader[SampleConfig]([]byte("APP_NAME=from-bytes\nPORT=8080\n"))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	assertConfigValues(t, cfg, &SampleConfig{AppName: "from-bytes", Port: 8080})
+}
+
+func TestBytesLoaderProcessEnvironmentWins(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	if err := os.Setenv("PORT", "9090"); err != nil {
+		t.Fatalf("failed to set PORT: %v", err)
+	}
+
+	loader, err := env.NewBytesLoader[SampleConfig]([]byte("APP_NAME=from-bytes\nPORT=8080\n"))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	assertConfigValues(t, cfg, &SampleConfig{AppName: "from-bytes", Port: 9090})
+}
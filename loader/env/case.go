@@ -0,0 +1,45 @@
+package env
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// matchKeysCaseInsensitive copies the value of a variable matching a field key only
+// case-insensitively to the key itself, e.g. port to PORT. A variable matching the key
+// exactly always wins; among several case variants, the first in byte order is used.
+// Variants that were copied are removed unless they are keys of other fields.
+func matchKeysCaseInsensitive(environ map[string]string, keys []fields.Field) {
+	known := make(map[string]bool, len(keys))
+	for _, f := range keys {
+		known[f.Key] = true
+	}
+
+	variants := make(map[string][]string)
+	for name := range environ {
+		upper := strings.ToUpper(name)
+		variants[upper] = append(variants[upper], name)
+	}
+
+	for _, f := range keys {
+		if _, exact := environ[f.Key]; exact {
+			continue
+		}
+
+		candidates := variants[strings.ToUpper(f.Key)]
+		if len(candidates) == 0 {
+			continue
+		}
+
+		slices.Sort(candidates)
+		environ[f.Key] = environ[candidates[0]]
+
+		for _, name := range candidates {
+			if !known[name] {
+				delete(environ, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLoaderWithCaseInsensitiveKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ map[string]string
+		want    SampleConfig
+	}{
+		{
+			name:    "Lowercase variable",
+			environ: map[string]string{"port": "8080", "app_name": "lower"},
+			want:    SampleConfig{AppName: "lower", Port: 8080},
+		},
+		{
+			name:    "Exact match wins",
+			environ: map[string]string{"PORT": "8080", "port": "9090"},
+			want:    SampleConfig{Port: 8080},
+		},
+		{
+			name:    "First variant in byte order wins",
+			environ: map[string]string{"port": "9090", "Port": "8081"},
+			want:    SampleConfig{Port: 8081},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader, err := env.NewLoader[SampleConfig](nil,
+				env.WithEnvironment(tc.environ),
+				env.WithCaseInsensitiveKeys(),
+			)
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			assertConfigValues(t, cfg, &tc.want)
+		})
+	}
+}
+
+func TestLoaderWithCaseInsensitiveKeysStrict(t *testing.T) {
+	loader, err := env.NewLoader[SampleConfig](nil,
+		env.WithEnvironment(map[string]string{"APP_port": "8080"}),
+		env.WithEnvPrefix("APP_"),
+		env.WithCaseInsensitiveKeys(),
+		env.WithStrict(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Port != 8080 {
+		t.Errorf("expected port 8080, got %d", cfg.Port)
+	}
+}
@@ -0,0 +1,46 @@
+package env
+
+import (
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// cleanValues applies WithTrimSpace and WithStripQuotes to the values of the given keys.
+// With both enabled, whitespace is trimmed both outside and inside the quotes.
+func cleanValues(environ map[string]string, keys []fields.Field, trimSpace, stripQuotes bool) {
+	for _, f := range keys {
+		value, ok := environ[f.Key]
+		if !ok {
+			continue
+		}
+
+		if trimSpace {
+			value = strings.TrimSpace(value)
+		}
+
+		if stripQuotes {
+			value = stripMatchingQuotes(value)
+
+			if trimSpace {
+				value = strings.TrimSpace(value)
+			}
+		}
+
+		environ[f.Key] = value
+	}
+}
+
+// stripMatchingQuotes removes one pair of matching single or double quotes surrounding s,
+// leaving quotes inside the value untouched
+func stripMatchingQuotes(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+
+	if first, last := s[0], s[len(s)-1]; first == last && (first == '"' || first == '\'') {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
@@ -0,0 +1,119 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLoaderWithTrimSpaceAndStripQuotes(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ map[string]string
+		opts    []env.Option
+		want    SampleConfig
+	}{
+		{
+			name:    "Quoted numeric value",
+			environ: map[string]string{"PORT": `"8080"`, "APP_NAME": `'billing'`},
+			opts:    []env.Option{env.WithStripQuotes()},
+			want:    SampleConfig{AppName: "billing", Port: 8080},
+		},
+		{
+			name:    "Space-padded value",
+			environ: map[string]string{"PORT": " 8080 ", "APP_NAME": "\tbilling\n"},
+			opts:    []env.Option{env.WithTrimSpace()},
+			want:    SampleConfig{AppName: "billing", Port: 8080},
+		},
+		{
+			name:    "Padded quotes with padded content",
+			environ: map[string]string{"PORT": ` " 8080 " `},
+			opts:    []env.Option{env.WithTrimSpace(), env.WithStripQuotes()},
+			want:    SampleConfig{Port: 8080},
+		},
+		{
+			name:    "Unmatched and inner quotes are kept",
+			environ: map[string]string{"APP_NAME": `"say "hi"'`},
+			opts:    []env.Option{env.WithStripQuotes()},
+			want:    SampleConfig{AppName: `"say "hi"'`},
+		},
+		{
+			name:    "Only one pair is stripped",
+			environ: map[string]string{"APP_NAME": `""quoted""`},
+			opts:    []env.Option{env.WithStripQuotes()},
+			want:    SampleConfig{AppName: `"quoted"`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader, err := env.NewLoader[SampleConfig](nil, append(tc.opts, env.WithEnvironment(tc.environ))...)
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			assertConfigValues(t, cfg, &tc.want)
+		})
+	}
+}
+
+func TestLoaderWithTreatEmptyAsUnset(t *testing.T) {
+	type Config struct {
+		LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
+		Token    string `env:"TOKEN,required"`
+	}
+
+	t.Run("Default applies", func(t *testing.T) {
+		loader, err := env.NewLoader[Config](nil,
+			env.WithTreatEmptyAsUnset(),
+			env.WithEnvironment(map[string]string{"LOG_LEVEL": "", "TOKEN": "t0k3n"}),
+		)
+		if err != nil {
+			t.Fatalf("failed to create env loader: %v", err)
+		}
+
+		cfg, err := loader.Load()
+		if err != nil {
+			t.Fatalf("unexpected error loading config: %v", err)
+		}
+
+		if cfg.LogLevel != "info" {
+			t.Errorf("LogLevel: expected default %q, got %q", "info", cfg.LogLevel)
+		}
+	})
+
+	t.Run("Required fails", func(t *testing.T) {
+		environ := map[string]string{"TOKEN": ""}
+
+		loader, err := env.NewLoader[Config](nil, env.WithEnvironment(environ))
+		if err != nil {
+			t.Fatalf("failed to create env loader: %v", err)
+		}
+
+		if _, err := loader.Load(); err != nil {
+			t.Fatalf("expected an empty required variable to pass without the option, got %v", err)
+		}
+
+		loader, err = env.NewLoader[Config](nil, env.WithTreatEmptyAsUnset(), env.WithEnvironment(environ))
+		if err != nil {
+			t.Fatalf("failed to create env loader: %v", err)
+		}
+
+		_, err = loader.Load()
+
+		var loadErr *env.LoadError
+		if !errors.As(err, &loadErr) {
+			t.Fatalf("expected *env.LoadError, got %T: %v", err, err)
+		}
+
+		if len(loadErr.Fields) != 1 || loadErr.Fields[0].Key != "TOKEN" {
+			t.Errorf("expected a field error for TOKEN, got %+v", loadErr.Fields)
+		}
+	})
+}
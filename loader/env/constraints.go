@@ -0,0 +1,177 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ErrConstraintViolation indicates that a loaded value does not satisfy a constraint tag.
+var ErrConstraintViolation = errors.New("constraint violation")
+
+// checkConstraints validates the constraint tags (minItems, maxItems, validate, requiredUnless) of every field in cfg
+func checkConstraints(cfg any, walker fields.Walker) error {
+	root := reflect.ValueOf(cfg)
+
+	var errs []error
+	walker.Walk(root.Type(), func(f fields.Field) {
+		v, ok := fields.Value(root, f.Index)
+		if !ok {
+			return
+		}
+
+		if err := checkItems(f, v); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := checkValidate(f, v); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := checkRequiredUnless(root, f, v); err != nil {
+			errs = append(errs, err)
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// checkItems enforces the minItems and maxItems tags on slice fields
+func checkItems(f fields.Field, v reflect.Value) error {
+	minTag, hasMin := f.Struct.Tag.Lookup("minItems")
+	maxTag, hasMax := f.Struct.Tag.Lookup("maxItems")
+	if !hasMin && !hasMax {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	length := 0
+	if v.IsValid() {
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return fmt.Errorf("%s: minItems/maxItems require a slice field, got %s", f.Key, f.Struct.Type)
+		}
+
+		length = v.Len()
+	}
+
+	if hasMin {
+		limit, err := strconv.Atoi(minTag)
+		if err != nil {
+			return fmt.Errorf("%s: invalid minItems tag %q: %w", f.Key, minTag, err)
+		}
+
+		if length < limit {
+			return fmt.Errorf("%w: %s: expected at least %d item(s), got %d", ErrConstraintViolation, f.Key, limit, length)
+		}
+	}
+
+	if hasMax {
+		limit, err := strconv.Atoi(maxTag)
+		if err != nil {
+			return fmt.Errorf("%s: invalid maxItems tag %q: %w", f.Key, maxTag, err)
+		}
+
+		if length > limit {
+			return fmt.Errorf("%w: %s: expected at most %d item(s), got %d", ErrConstraintViolation, f.Key, limit, length)
+		}
+	}
+
+	return nil
+}
+
+// checkRequiredUnless enforces the requiredUnless tag, which names a sibling field that,
+// when set to a non-zero value, makes the tagged field optional
+func checkRequiredUnless(root reflect.Value, f fields.Field, v reflect.Value) error {
+	other, ok := f.Struct.Tag.Lookup("requiredUnless")
+	if !ok {
+		return nil
+	}
+
+	parent, ok := fields.Value(root, f.Index[:len(f.Index)-1])
+	if !ok {
+		return nil
+	}
+
+	for parent.Kind() == reflect.Pointer {
+		parent = parent.Elem()
+	}
+
+	sibling := parent.FieldByName(other)
+	if !sibling.IsValid() {
+		return fmt.Errorf("%s: requiredUnless refers to unknown field %q", f.Key, other)
+	}
+
+	if v.IsZero() && sibling.IsZero() {
+		return fmt.Errorf("%w: %s: required unless %s is set", ErrConstraintViolation, f.Key, other)
+	}
+
+	return nil
+}
+
+// checkValidate enforces the rules listed in the validate tag. Rules it does not know are
+// skipped, so the tag can be shared with other validators such as go-playground/validator.
+// The url rule is only checked by the opt-in loader/validate package.
+func checkValidate(f fields.Field, v reflect.Value) error {
+	tag, ok := f.Struct.Tag.Lookup("validate")
+	if !ok {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		switch rule = strings.TrimSpace(rule); rule {
+		case "origins":
+			if err := checkOrigins(f, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkOrigins requires every item of a string slice to be "*" or a scheme://host[:port] origin
+func checkOrigins(f fields.Field, v reflect.Value) error {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.String {
+		return fmt.Errorf("%s: validate:\"origins\" requires a string slice field, got %s", f.Key, f.Struct.Type)
+	}
+
+	for i := range v.Len() {
+		origin := v.Index(i).String()
+		if !isValidOrigin(origin) {
+			return fmt.Errorf("%w: %s: invalid origin %q", ErrConstraintViolation, f.Key, origin)
+		}
+	}
+
+	return nil
+}
+
+// isValidOrigin reports whether s is "*" or an origin with only a scheme, host and optional port
+func isValidOrigin(s string) bool {
+	if s == "*" {
+		return true
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme != "" && u.Hostname() != "" && u.User == nil &&
+		u.Path == "" && u.RawQuery == "" && !u.ForceQuery && u.Fragment == ""
+}
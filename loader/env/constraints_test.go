@@ -0,0 +1,204 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type UpstreamConfig struct {
+	Upstreams []string `env:"UPSTREAMS" minItems:"1" maxItems:"3"`
+}
+
+func TestLoaderSliceItemConstraints(t *testing.T) {
+	tests := []struct {
+		name        string
+		envContent  string
+		expectError bool
+	}{
+		{
+			name:        "Empty slice fails minItems",
+			envContent:  "OTHER=1",
+			expectError: true,
+		},
+		{
+			name:       "Populated slice passes",
+			envContent: "UPSTREAMS=a.internal,b.internal",
+		},
+		{
+			name:        "Over-max slice fails maxItems",
+			envContent:  "UPSTREAMS=a,b,c,d",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer clearEnvironmentVariables("UPSTREAMS", "OTHER")
+
+			file := createTempEnvFile(t, tc.envContent)
+
+			loader, err := env.NewLoader[UpstreamConfig]([]string{file})
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			_, err = loader.Load()
+			if tc.expectError && !errors.Is(err, env.ErrConstraintViolation) {
+				t.Fatalf("expected ErrConstraintViolation, got %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+		})
+	}
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `env:"ALLOWED_ORIGINS" validate:"origins"`
+}
+
+func TestLoaderOriginsValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		envContent  string
+		expectError bool
+	}{
+		{
+			name:       "Valid origins pass",
+			envContent: "ALLOWED_ORIGINS=https://example.com,http://localhost:3000",
+		},
+		{
+			name:       "Wildcard passes",
+			envContent: "ALLOWED_ORIGINS=*",
+		},
+		{
+			name:        "Origin with a path fails",
+			envContent:  "ALLOWED_ORIGINS=https://example.com/app",
+			expectError: true,
+		},
+		{
+			name:        "Origin without a scheme fails",
+			envContent:  "ALLOWED_ORIGINS=example.com",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer clearEnvironmentVariables("ALLOWED_ORIGINS")
+
+			file := createTempEnvFile(t, tc.envContent)
+
+			loader, err := env.NewLoader[CORSConfig]([]string{file})
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			_, err = loader.Load()
+			if tc.expectError && !errors.Is(err, env.ErrConstraintViolation) {
+				t.Fatalf("expected ErrConstraintViolation, got %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoaderValidateSkipsUnknownRules(t *testing.T) {
+	type Config struct {
+		Name           string   `env:"NAME" validate:"required,min=1"`
+		AllowedOrigins []string `env:"ALLOWED_ORIGINS" validate:"required,origins"`
+	}
+
+	loader, err := env.NewLoader[Config](nil, env.WithEnvironment(map[string]string{
+		"NAME":            "billing",
+		"ALLOWED_ORIGINS": "https://example.com",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	loader, err = env.NewLoader[Config](nil, env.WithEnvironment(map[string]string{
+		"NAME":            "billing",
+		"ALLOWED_ORIGINS": "example.com",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, env.ErrConstraintViolation) {
+		t.Fatalf("expected origins rule to still apply, got %v", err)
+	}
+}
+
+func TestLoaderDoesNotCheckURLRule(t *testing.T) {
+	type Config struct {
+		BaseURL string `env:"BASE_URL" validate:"url"`
+	}
+
+	loader, err := env.NewLoader[Config](nil, env.WithEnvironment(map[string]string{
+		"BASE_URL": "/relative/path",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("expected url rule to be left to loader/validate, got %v", err)
+	}
+}
+
+type ProviderConfig struct {
+	StaticConfigPath string `env:"STATIC_CONFIG_PATH" requiredUnless:"DynamicProvider"`
+	DynamicProvider  string `env:"DYNAMIC_PROVIDER"`
+}
+
+func TestLoaderRequiredUnless(t *testing.T) {
+	tests := []struct {
+		name        string
+		envContent  string
+		expectError bool
+	}{
+		{
+			name:       "Other field set",
+			envContent: "DYNAMIC_PROVIDER=consul",
+		},
+		{
+			name:        "Neither field set",
+			envContent:  "OTHER=1",
+			expectError: true,
+		},
+		{
+			name:       "Both fields set",
+			envContent: "STATIC_CONFIG_PATH=/etc/app.json\nDYNAMIC_PROVIDER=consul",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer clearEnvironmentVariables("STATIC_CONFIG_PATH", "DYNAMIC_PROVIDER", "OTHER")
+
+			file := createTempEnvFile(t, tc.envContent)
+
+			loader, err := env.NewLoader[ProviderConfig]([]string{file})
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			_, err = loader.Load()
+			if tc.expectError && !errors.Is(err, env.ErrConstraintViolation) {
+				t.Fatalf("expected ErrConstraintViolation, got %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package env
+
+import (
+	"reflect"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// KeyInfo describes a single environment key used by a configuration type
+type KeyInfo struct {
+	// Key is the fully prefixed environment variable name
+	Key string
+
+	// Field is the dotted Go path of the field, e.g. "Database.Host"
+	Field string
+
+	// Type is the Go type of the field
+	Type string
+
+	// Required reports whether the key is marked as required
+	Required bool
+
+	// Default holds the envDefault value, if any
+	Default string
+
+	// Secret reports whether the field is tagged with secret:"true"
+	Secret bool
+}
+
+// DescribeKeys returns the environment keys used by the configuration type T,
+// recursing into nested structs and applying their envPrefix tags.
+// It returns nil if T is not a struct.
+func DescribeKeys[T any]() []KeyInfo {
+	var keys []KeyInfo
+	(fields.Walker{}).Walk(reflect.TypeFor[T](), func(f fields.Field) {
+		keys = append(keys, KeyInfo{
+			Key:      f.Key,
+			Field:    f.Name,
+			Type:     f.Struct.Type.String(),
+			Required: f.Required(),
+			Default:  f.Default,
+			Secret:   f.Secret(),
+		})
+	})
+
+	return keys
+}
@@ -0,0 +1,33 @@
+package env_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type DescribeConfig struct {
+	AppName string        `env:"APP_NAME" envDefault:"myapp"`
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"5s"`
+
+	Database struct {
+		Host     string `env:"HOST,required"`
+		Password string `env:"PASSWORD" secret:"true"`
+	} `envPrefix:"DB_"`
+}
+
+func TestDescribeKeys(t *testing.T) {
+	want := []env.KeyInfo{
+		{Key: "APP_NAME", Field: "AppName", Type: "string", Default: "myapp"},
+		{Key: "TIMEOUT", Field: "Timeout", Type: "time.Duration", Default: "5s"},
+		{Key: "DB_HOST", Field: "Database.Host", Type: "string", Required: true},
+		{Key: "DB_PASSWORD", Field: "Database.Password", Type: "string", Secret: true},
+	}
+
+	got := env.DescribeKeys[DescribeConfig]()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DescribeKeys mismatch\nexpected: %+v\ngot:      %+v", want, got)
+	}
+}
@@ -0,0 +1,61 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestDirLoader(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"10-base.env":     "APP_NAME=base\nPORT=8080\n",
+		"20-service.env":  "APP_NAME=service\n",
+		"30-override.env": "PORT=9090\n",
+		"notes.txt":       "PORT=1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	loader, err := env.NewDirLoader[SampleConfig](dir)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	assertConfigValues(t, cfg, &SampleConfig{AppName: "service", Port: 9090})
+}
+
+func TestDirLoaderMissingDirectory(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "conf.d")
+
+	loader, err := env.NewDirLoader[SampleConfig](missing)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, env.ErrSourceNotFound) {
+		t.Fatalf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	loader, err = env.NewDirLoader[SampleConfig](t.TempDir(), env.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("expected empty directory to be skipped, got %v", err)
+	}
+}
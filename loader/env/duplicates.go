@@ -0,0 +1,34 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ErrDuplicateKey indicates that several fields of the configuration type resolve to the same environment key.
+var ErrDuplicateKey = errors.New("duplicate environment key")
+
+// checkDuplicateKeys reports every environment key that more than one field resolves to,
+// naming the fields in declaration order
+func checkDuplicateKeys(keys []fields.Field) error {
+	names := make(map[string][]string, len(keys))
+	var order []string
+	for _, f := range keys {
+		if _, seen := names[f.Key]; !seen {
+			order = append(order, f.Key)
+		}
+		names[f.Key] = append(names[f.Key], f.Name)
+	}
+
+	var errs []error
+	for _, key := range order {
+		if len(names[key]) > 1 {
+			errs = append(errs, fmt.Errorf("%w %s used by %s", ErrDuplicateKey, key, strings.Join(names[key], ", ")))
+		}
+	}
+
+	return errors.Join(errs...)
+}
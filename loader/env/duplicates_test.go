@@ -0,0 +1,72 @@
+package env_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type CollidingConfig struct {
+	AppHost string `env:"APP_HOST"`
+
+	App struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	} `envPrefix:"APP_"`
+
+	AppPort int `env:"APP_PORT"`
+}
+
+func TestNewLoaderDuplicateKeys(t *testing.T) {
+	_, err := env.NewLoader[CollidingConfig]([]string{".env"})
+	if !errors.Is(err, env.ErrDuplicateKey) {
+		t.Fatalf("expected ErrDuplicateKey, got %v", err)
+	}
+
+	for _, want := range []string{
+		"APP_HOST used by AppHost, App.Host",
+		"APP_PORT used by App.Port, AppPort",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestNewLoaderDuplicateKeysWithPrefix(t *testing.T) {
+	type Config struct {
+		Host string `env:"DB_HOST"`
+
+		Database struct {
+			Host string `env:"HOST"`
+		} `envPrefix:"DB_"`
+	}
+
+	_, err := env.NewLoader[Config]([]string{".env"}, env.WithEnvPrefix("APP_"))
+	if err == nil || !strings.Contains(err.Error(), "APP_DB_HOST used by Host, Database.Host") {
+		t.Errorf("expected duplicate APP_DB_HOST error, got %v", err)
+	}
+}
+
+type treeNode struct {
+	Name  string    `env:"NAME"`
+	Child *treeNode `envPrefix:"CHILD_"`
+}
+
+func TestNewLoaderRecursiveType(t *testing.T) {
+	loader, err := env.NewLoader[treeNode](nil, env.WithEnvironment(map[string]string{"NAME": "root"}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "root" {
+		t.Errorf("expected name root, got %q", cfg.Name)
+	}
+}
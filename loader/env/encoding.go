@@ -0,0 +1,109 @@
+package env
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ErrInvalidEncoding indicates that a value tagged with an encoding could not be decoded.
+var ErrInvalidEncoding = errors.New("invalid encoded value")
+
+// decoders maps the supported values of the encoding tag to their decode functions
+var decoders = map[string]func(string) ([]byte, error){
+	"base64": base64.StdEncoding.DecodeString,
+	"hex":    hex.DecodeString,
+}
+
+// encoders maps the supported values of the encoding tag to their encode functions
+var encoders = map[string]func([]byte) string{
+	"base64": base64.StdEncoding.EncodeToString,
+	"hex":    hex.EncodeToString,
+}
+
+// decodeValues decodes the values of string and []byte fields tagged with encoding:"base64"
+// or encoding:"hex" in environ, falling back to their envDefault when the key is unset or empty.
+// Decoded []byte values are rewritten in the separator-joined form the env parser expects.
+func decodeValues(environ map[string]string, keys []fields.Field) error {
+	for _, f := range keys {
+		encoding, ok := f.Struct.Tag.Lookup("encoding")
+		if !ok {
+			continue
+		}
+
+		decode, ok := decoders[encoding]
+		if !ok {
+			return fmt.Errorf("field %s: unknown encoding %q", f.Name, encoding)
+		}
+
+		t := f.Struct.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		isBytes := t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+		if t.Kind() != reflect.String && !isBytes {
+			return fmt.Errorf("field %s: encoding tag requires a string or []byte field, got %s", f.Name, f.Struct.Type)
+		}
+
+		raw, ok := environ[f.Key]
+		if (!ok || raw == "") && f.HasDefault {
+			raw, ok = f.Default, true
+		}
+
+		if !ok {
+			continue
+		}
+
+		decoded, err := decode(raw)
+		if err != nil {
+			return fmt.Errorf("%w: field %s (%s) is not valid %s: %v", ErrInvalidEncoding, f.Name, f.Key, encoding, err)
+		}
+
+		if !isBytes {
+			environ[f.Key] = string(decoded)
+			continue
+		}
+
+		separator := f.Struct.Tag.Get("envSeparator")
+		if separator == "" {
+			separator = ","
+		}
+
+		items := make([]string, len(decoded))
+		for i, b := range decoded {
+			items[i] = strconv.Itoa(int(b))
+		}
+
+		environ[f.Key] = strings.Join(items, separator)
+	}
+
+	return nil
+}
+
+// encodeValue encodes a string or []byte value with the given encoding, reversing decodeValues
+func encodeValue(v reflect.Value, encoding string) (string, error) {
+	encode, ok := encoders[encoding]
+	if !ok {
+		return "", fmt.Errorf("unknown encoding %q", encoding)
+	}
+
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.String:
+		return encode([]byte(v.String())), nil
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return encode(v.Bytes()), nil
+	default:
+		return "", fmt.Errorf("encoding tag requires a string or []byte field, got %s", v.Type())
+	}
+}
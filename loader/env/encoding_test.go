@@ -0,0 +1,92 @@
+package env_test
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type EncodedConfig struct {
+	Password   string `env:"PASSWORD" encoding:"base64"`
+	SigningKey []byte `env:"SIGNING_KEY" encoding:"hex"`
+	Token      []byte `env:"TOKEN" encoding:"base64" envDefault:"ZGVmYXVsdA=="`
+}
+
+func TestLoaderDecodesEncodedFields(t *testing.T) {
+	defer clearEnvironmentVariables("PASSWORD", "SIGNING_KEY", "TOKEN")
+
+	file := createTempEnvFile(t, "PASSWORD=czNjcjN0\nSIGNING_KEY=deadbeef\n")
+
+	loader, err := env.NewLoader[EncodedConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Password != "s3cr3t" {
+		t.Errorf("expected base64-decoded password, got %q", cfg.Password)
+	}
+	if !bytes.Equal(cfg.SigningKey, []byte{0xde, 0xad, 0xbe, 0xef}) {
+		t.Errorf("expected hex-decoded signing key, got %x", cfg.SigningKey)
+	}
+	if string(cfg.Token) != "default" {
+		t.Errorf("expected decoded default token, got %q", cfg.Token)
+	}
+}
+
+func TestLoaderInvalidEncodedField(t *testing.T) {
+	defer clearEnvironmentVariables("PASSWORD", "SIGNING_KEY")
+
+	file := createTempEnvFile(t, "PASSWORD=czNjcjN0\nSIGNING_KEY=not-hex\n")
+
+	loader, err := env.NewLoader[EncodedConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	_, err = loader.Load()
+	if !errors.Is(err, env.ErrInvalidEncoding) {
+		t.Fatalf("expected ErrInvalidEncoding, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "SigningKey") {
+		t.Errorf("expected error to name the field, got %v", err)
+	}
+}
+
+func TestSaveEnvFileEncodesFields(t *testing.T) {
+	defer clearEnvironmentVariables("PASSWORD", "SIGNING_KEY", "TOKEN")
+
+	want := &EncodedConfig{
+		Password:   "s3cr3t",
+		SigningKey: []byte{0xde, 0xad, 0xbe, 0xef},
+		Token:      []byte("token"),
+	}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := env.SaveEnvFile(want, path); err != nil {
+		t.Fatalf("unexpected error saving env file: %v", err)
+	}
+
+	loader, err := env.NewLoader[EncodedConfig]([]string{path})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	got, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch\nexpected: %+v\ngot:      %+v", want, got)
+	}
+}
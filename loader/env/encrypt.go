@@ -0,0 +1,114 @@
+package env
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedPrefix marks values encrypted by SaveEnvFileEncrypted
+const encryptedPrefix = "enc:aesgcm:"
+
+// ErrDecryptionFailed indicates that an encrypted value could not be decrypted, usually
+// because of a wrong key or a tampered file.
+var ErrDecryptionFailed = errors.New("decryption failed")
+
+// SaveEnvFileEncrypted writes cfg like SaveEnvFile, but encrypts the values of fields tagged
+// secret:"true" with AES-GCM under key, which must be 16, 24 or 32 bytes long. Encrypted values
+// are written as enc:aesgcm:<base64 nonce and ciphertext>; other fields stay plaintext. Each
+// value is bound to its variable name, so it cannot be moved to another key.
+// Use LoadEncrypted to read the file back.
+func SaveEnvFileEncrypted[T any](cfg *T, path string, key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return fmt.Errorf("error saving env file %s: %w", path, err)
+	}
+
+	content, err := marshalEnv(cfg, func(key, value string) (string, error) {
+		return encryptValue(aead, key, value)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving env file %s: %w", path, err)
+	}
+
+	if err := writeFileAtomic(path, []byte(content)); err != nil {
+		return fmt.Errorf("error saving env file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadEncrypted loads a file written by SaveEnvFileEncrypted, decrypting every enc:aesgcm:
+// value with key. Decrypted values never enter the process environment: the configuration
+// is read from the file alone, as with WithEnvironment. opts configure parsing as for NewLoader.
+func LoadEncrypted[T any](path string, key []byte, opts ...Option) (*T, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("error loading env file %s: %w", path, err)
+	}
+
+	environ, err := readEnvFile(context.Background(), path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading env file %s: %w", path, err)
+	}
+
+	for k, value := range environ {
+		if !strings.HasPrefix(value, encryptedPrefix) {
+			continue
+		}
+
+		if environ[k], err = decryptValue(aead, k, value); err != nil {
+			return nil, fmt.Errorf("error loading env file %s: %s: %w", path, k, err)
+		}
+	}
+
+	loader, err := NewLoader[T](nil, append(opts, WithEnvironment(environ))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.Load()
+}
+
+// newAEAD creates an AES-GCM cipher for key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptValue encrypts value under a random nonce and encodes it with encryptedPrefix.
+// The env key is passed as additional data, so decryptValue only accepts it under that key.
+func encryptValue(aead cipher.AEAD, key, value string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(key))
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptValue reverses encryptValue
+func decryptValue(aead cipher.AEAD, key, value string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: malformed value", ErrDecryptionFailed)
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,99 @@
+package env_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type EncryptedConfig struct {
+	AppName  string `env:"APP_NAME"`
+	Password string `env:"DB_PASSWORD" secret:"true"`
+}
+
+func TestSaveEnvFileEncryptedRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	want := &EncryptedConfig{AppName: "billing", Password: "s3cr3t-p@ss"}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := env.SaveEnvFileEncrypted(want, path, key); err != nil {
+		t.Fatalf("unexpected error saving encrypted env file: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read env file: %v", err)
+	}
+
+	if strings.Contains(string(data), want.Password) {
+		t.Errorf("expected the secret to be encrypted on disk, got:\n%s", data)
+	}
+
+	if !strings.Contains(string(data), "APP_NAME=billing") {
+		t.Errorf("expected non-secret fields in plaintext, got:\n%s", data)
+	}
+
+	got, err := env.LoadEncrypted[EncryptedConfig](path, key)
+	if err != nil {
+		t.Fatalf("unexpected error loading encrypted env file: %v", err)
+	}
+
+	if *got != *want {
+		t.Errorf("round trip mismatch\nexpected: %+v\ngot:      %+v", want, got)
+	}
+
+	if _, exists := os.LookupEnv("DB_PASSWORD"); exists {
+		t.Error("expected the decrypted secret not to enter the process environment")
+	}
+}
+
+func TestLoadEncryptedWrongKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	cfg := &EncryptedConfig{Password: "s3cr3t"}
+	if err := env.SaveEnvFileEncrypted(cfg, path, bytes.Repeat([]byte{1}, 32)); err != nil {
+		t.Fatalf("unexpected error saving encrypted env file: %v", err)
+	}
+
+	_, err := env.LoadEncrypted[EncryptedConfig](path, bytes.Repeat([]byte{2}, 32))
+	if !errors.Is(err, env.ErrDecryptionFailed) {
+		t.Fatalf("expected ErrDecryptionFailed, got %v", err)
+	}
+}
+
+func TestLoadEncryptedSwappedValues(t *testing.T) {
+	type Config struct {
+		User     string `env:"DB_USER" secret:"true"`
+		Password string `env:"DB_PASSWORD" secret:"true"`
+	}
+
+	key := bytes.Repeat([]byte{0x42}, 32)
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := env.SaveEnvFileEncrypted(&Config{User: "app", Password: "s3cr3t"}, path, key); err != nil {
+		t.Fatalf("unexpected error saving encrypted env file: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read env file: %v", err)
+	}
+
+	values := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		name, value, _ := strings.Cut(line, "=")
+		values[name] = value
+	}
+
+	swapped := "DB_USER=" + values["DB_PASSWORD"] + "\nDB_PASSWORD=" + values["DB_USER"] + "\n"
+	if err := os.WriteFile(path, []byte(swapped), 0o600); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+
+	if _, err := env.LoadEncrypted[Config](path, key); !errors.Is(err, env.ErrDecryptionFailed) {
+		t.Fatalf("expected ErrDecryptionFailed for swapped values, got %v", err)
+	}
+}
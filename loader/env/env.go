@@ -8,79 +8,676 @@
 package env
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
 )
 
 var (
-	// ErrEnvFilesNotSpecified indicates that the NewLoader function was called with an empty Files array.
+	// ErrEnvFilesNotSpecified indicates that the NewLoader function was called with an empty Files array
+	// and no other file source (such as WithEnvironmentFiles) was configured.
 	ErrEnvFilesNotSpecified = errors.New("env files not specified")
 
 	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
-	ErrSourceNotFound = errors.New("source not found")
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+
+	// ErrNotARegularFile indicates that an env file path refers to a directory. WithSkipMissingFiles
+	// does not skip it, since it points to a misconfiguration rather than a missing file.
+	ErrNotARegularFile = errors.New("env file is a directory, not a regular file")
+
+	// ErrNotAStruct indicates that the configuration type is not a struct.
+	ErrNotAStruct = errors.New("config type is not a struct")
+
+	// ErrStrictWithoutPrefix indicates that strict mode was enabled without an environment prefix.
+	ErrStrictWithoutPrefix = errors.New("strict mode requires an env prefix")
+
+	// ErrUnknownVariables indicates that strict mode found prefixed variables that match no field.
+	ErrUnknownVariables = errors.New("unknown environment variables")
+
+	// ErrRequiredFileNotListed indicates that WithRequiredFiles named a file that is not loaded by the loader.
+	ErrRequiredFileNotListed = errors.New("required file is not in the env files list")
+
+	// ErrEmptyPath indicates that an env file path expanded to an empty string, e.g. because
+	// it consists only of an unset variable.
+	ErrEmptyPath = errors.New("env file path is empty after expansion")
+
+	// ErrNilConfig indicates that a nil configuration was passed where a value is required.
+	ErrNilConfig = errors.New("config is nil")
 )
 
 // Loader implements configuration loading from environment variables
 type Loader[T any] struct {
 	Files   []string
+	Dir     string
+	Data    []byte
 	Options Options
 }
 
-// NewLoader creates a new environment-based config loader
+// NewLoader creates a new environment-based config loader.
+// File paths may start with ~ and reference environment variables ($VAR or ${VAR});
+// both are expanded on every Load.
 func NewLoader[T any](files []string, opts ...Option) (*Loader[T], error) {
-	if len(files) == 0 {
+	loader := &Loader[T]{
+		Files: files,
+	}
+
+	if err := loader.applyOptions(opts); err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 && loader.Options.EnvironmentVar == "" && loader.Options.Environment == nil {
+		return nil, ErrEnvFilesNotSpecified
+	}
+
+	for _, required := range loader.Options.RequiredFiles {
+		if !slices.Contains(files, required) {
+			return nil, fmt.Errorf("error creating loader: %w: %s", ErrRequiredFileNotListed, required)
+		}
+	}
+
+	return loader, nil
+}
+
+// NewDirLoader creates a config loader that loads every *.env file in dir.
+// Files are sorted lexically and later files take precedence over earlier ones,
+// following the conf.d convention (10-base.env is overridden by 20-local.env).
+// A missing directory, or one without *.env files, causes Load to return
+// ErrSourceNotFound unless WithSkipMissingFiles is set.
+func NewDirLoader[T any](dir string, opts ...Option) (*Loader[T], error) {
+	if dir == "" {
 		return nil, ErrEnvFilesNotSpecified
 	}
 
 	loader := &Loader[T]{
-		Files: files,
+		Dir: dir,
+	}
+
+	if err := loader.applyOptions(opts); err != nil {
+		return nil, err
+	}
+
+	return loader, nil
+}
+
+// NewBytesLoader creates a config loader that reads env-file formatted data from memory,
+// e.g. a secret fetched from an API. data takes precedence over any files configured
+// through options such as WithEnvironmentFiles, and the process environment takes
+// precedence over data. All parsing options of the file loader apply.
+func NewBytesLoader[T any](data []byte, opts ...Option) (*Loader[T], error) {
+	loader := &Loader[T]{
+		Data: data,
+	}
+
+	if err := loader.applyOptions(opts); err != nil {
+		return nil, err
 	}
 
+	return loader, nil
+}
+
+// applyOptions applies opts in order and validates the resulting combination
+func (l *Loader[T]) applyOptions(opts []Option) error {
 	for _, opt := range opts {
-		if err := opt(&loader.Options); err != nil {
-			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		if err := opt(&l.Options); err != nil {
+			return fmt.Errorf("error creating loader: invalid option: %w", err)
 		}
 	}
 
-	return loader, nil
+	if l.Options.Strict && l.envOptions().Prefix == "" {
+		return fmt.Errorf("error creating loader: invalid option: %w", ErrStrictWithoutPrefix)
+	}
+
+	if err := checkDuplicateKeys(l.walker().Fields(reflect.TypeFor[T]())); err != nil {
+		return fmt.Errorf("error creating loader: %w", err)
+	}
+
+	return nil
 }
 
-// Load loads the configuration from environment variables and files
+// Load loads the configuration from environment variables and files.
+// Parse failures are reported as a *LoadError describing each failing field. In that case
+// Load also returns the partially populated config, with every field that parsed successfully
+// filled in and post-processing such as WithOnLoad skipped, so a non-nil config may come with
+// a non-nil error. On any other error the config is nil.
+//
+// Pointer fields such as *int distinguish unset from zero: the pointer is allocated when
+// the variable is set to a non-empty value or, failing that, when the field has an envDefault
+// tag. Otherwise it stays nil; a variable set to the empty string counts as unset.
+// If *T implements goconfig.Unmarshaler, its LoadFrom method receives every visible
+// environment variable instead and tag-based parsing and post-processing are skipped;
+// callbacks registered with WithOnLoad still run.
 func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext loads the configuration like Load, aborting with ctx.Err() when ctx is done
+// before the env files have been read, e.g. because a network mount hangs.
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	start := time.Now()
+	cfg, files, err := l.load(ctx)
+
+	if l.Options.Observer != nil {
+		l.Options.Observer(ObserveEvent{
+			Loader:   loaderName,
+			Duration: time.Since(start),
+			Files:    len(files),
+			Err:      err,
+		})
+	}
+
+	return cfg, err
+}
+
+// load performs a single Load call and returns the env files that were read
+func (l *Loader[T]) load(ctx context.Context) (*T, []string, error) {
+	tr := newTracer(l.Options.Trace, l.Options.SecretAccessLog != nil)
+
 	// Load environment files using godotenv
-	for _, file := range l.Files {
-		if err := l.loadEnvFile(file); err != nil {
-			if l.Options.SkipMissingFiles && errors.Is(err, ErrSourceNotFound) {
+	files, err := l.loadFiles(ctx, tr)
+	if err != nil {
+		return nil, files, err
+	}
+
+	environ, err := l.environment(files)
+	if err != nil {
+		return nil, files, err
+	}
+
+	l.warnDeprecated(environ)
+
+	var cfg T
+	if unmarshaler, ok := any(&cfg).(goconfig.Unmarshaler); ok {
+		raw := make(map[string]any, len(environ))
+		for key, value := range environ {
+			raw[key] = value
+		}
+
+		if err := unmarshaler.LoadFrom(raw); err != nil {
+			return nil, files, fmt.Errorf("error loading config with LoadFrom: %w", err)
+		}
+
+		if err := l.runOnLoad(&cfg); err != nil {
+			return nil, files, err
+		}
+
+		return &cfg, files, nil
+	}
+
+	// Parse into struct using caarlos0/env
+	opts := l.envOptions()
+	opts.Environment = environ
+
+	if l.Options.ListSeparator != "" {
+		opts.FuncMap = withListParsers(opts.FuncMap, l.walker().Fields(reflect.TypeFor[T]()), l.Options.ListSeparator)
+	}
+
+	if tr != nil {
+		secrets := l.secretKeys()
+		onSet := opts.OnSet
+		opts.OnSet = func(tag string, value any, isDefault bool) {
+			// The parser also reports fields whose key is unset, with an empty value
+			if _, provided := environ[tag]; provided || isDefault {
+				tr.onSet(tag, isDefault)
+				if secrets[tag] {
+					l.logSecretAccess(tr, tag, isDefault)
+				}
+			}
+			if onSet != nil {
+				onSet(tag, value, isDefault)
+			}
+		}
+	}
+
+	start := time.Now()
+	err = env.ParseWithOptions(&cfg, opts)
+	l.logger().Debug("parsed env variables into struct", "duration", time.Since(start))
+
+	if err != nil {
+		// The env parser keeps going past failing fields, so cfg holds every value that parsed
+		return &cfg, files, newLoadError(err, environ, l.walker().Fields(reflect.TypeFor[T]()))
+	}
+
+	if l.Options.MapCollection {
+		if err := collectMaps(&cfg, l.walker(), environ); err != nil {
+			return nil, files, fmt.Errorf("error collecting map variables: %w", err)
+		}
+	}
+
+	if l.Options.LowerMapKeys {
+		if err := lowerMapKeys(&cfg, l.walker()); err != nil {
+			return nil, files, fmt.Errorf("error normalizing map keys: %w", err)
+		}
+	}
+
+	if l.Options.SortSlices {
+		if err := goconfig.SortSlices(&cfg); err != nil {
+			return nil, files, fmt.Errorf("error sorting slices: %w", err)
+		}
+	}
+
+	if err := l.normalize(&cfg); err != nil {
+		return nil, files, fmt.Errorf("error normalizing config: %w", err)
+	}
+
+	if err := checkConstraints(&cfg, l.walker()); err != nil {
+		return nil, files, fmt.Errorf("error validating config: %w", err)
+	}
+
+	if err := l.runOnLoad(&cfg); err != nil {
+		return nil, files, err
+	}
+
+	return &cfg, files, nil
+}
+
+// normalize applies normalize tags and then the normalizers registered with WithNormalizer
+func (l *Loader[T]) normalize(cfg *T) error {
+	if err := normalizeFields(cfg, l.walker()); err != nil {
+		return err
+	}
+
+	for _, fn := range l.Options.Normalizers {
+		if err := fn(cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filesByPrecedence returns the files passed to NewLoader from highest to lowest precedence.
+// Files are loaded in that order because a loaded variable is never overwritten.
+func (l *Loader[T]) filesByPrecedence() []string {
+	if l.Options.FileOrder == LowestFirst {
+		files := slices.Clone(l.Files)
+		slices.Reverse(files)
+		return files
+	}
+
+	return l.Files
+}
+
+// runOnLoad runs the callbacks registered with WithOnLoad in order, stopping at the first error
+func (l *Loader[T]) runOnLoad(cfg *T) error {
+	for _, fn := range l.Options.OnLoad {
+		if err := fn(cfg); err != nil {
+			return fmt.Errorf("error running OnLoad callback: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadFiles loads every configured env file into the process environment
+// and returns the files that were read
+func (l *Loader[T]) loadFiles(ctx context.Context, tr *tracer) ([]string, error) {
+	if l.Options.Environment != nil {
+		return nil, nil
+	}
+
+	var loaded []string
+
+	if l.Data != nil {
+		values, err := parseEnvData(l.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error loading env data: %w", err)
+		}
+
+		l.logger().Debug("loaded env data", "keys", len(values))
+		if err := l.applyValues(sourceData, values, tr); err != nil {
+			return nil, fmt.Errorf("error loading env data: %w", err)
+		}
+	}
+
+	for _, name := range l.filesByPrecedence() {
+		file, err := expandPath(name)
+		if err != nil {
+			return loaded, fmt.Errorf("error loading env file %s: %w", name, err)
+		}
+
+		if err := l.loadEnvFile(ctx, file, tr); err != nil {
+			if l.skipMissing(name) && errors.Is(err, ErrSourceNotFound) {
+				l.logger().Info("skipped missing env file", "file", file)
+				tr.fileSkipped(file)
 				continue
 			}
 
-			return nil, fmt.Errorf("error loading env file %s: %w", file, err)
+			return loaded, fmt.Errorf("error loading env file %s: %w", file, err)
 		}
+
+		loaded = append(loaded, file)
 	}
 
-	// Parse into struct using caarlos0/env
-	var cfg T
-	if err := env.ParseWithOptions(&cfg, l.Options.EnvOptions); err != nil {
-		// Just wrap the error with some context - caarlos0/env already provides good error messages
-		return nil, fmt.Errorf("error parsing env variables into struct: %w", err)
+	if l.Options.RequireAnyFile && len(l.Files) > 0 && len(loaded) == 0 {
+		return loaded, fmt.Errorf("error loading env files: none of %s exists: %w", strings.Join(l.Files, ", "), ErrSourceNotFound)
+	}
+
+	dirFiles, err := l.loadDir(ctx, tr)
+	loaded = append(loaded, dirFiles...)
+	if err != nil {
+		return loaded, err
+	}
+
+	for _, file := range l.environmentFiles() {
+		if err := l.loadEnvFile(ctx, file, tr); err != nil {
+			if errors.Is(err, ErrSourceNotFound) {
+				l.logger().Info("skipped missing env file", "file", file)
+				tr.fileSkipped(file)
+				continue
+			}
+
+			return loaded, fmt.Errorf("error loading env file %s: %w", file, err)
+		}
+
+		loaded = append(loaded, file)
+	}
+
+	return loaded, nil
+}
+
+// expandPath expands environment variables and a leading ~ in an env file path
+func expandPath(path string) (string, error) {
+	expanded := os.ExpandEnv(path)
+
+	if expanded == "~" || strings.HasPrefix(expanded, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand ~: %w", err)
+		}
+
+		expanded = filepath.Join(home, expanded[1:])
+	}
+
+	if expanded == "" {
+		return "", ErrEmptyPath
+	}
+
+	return expanded, nil
+}
+
+// skipMissing reports whether a missing file should be skipped rather than fail the load
+func (l *Loader[T]) skipMissing(file string) bool {
+	return l.Options.SkipMissingFiles && !slices.Contains(l.Options.RequiredFiles, file)
+}
+
+// loadDir loads the *.env files of the configured directory and returns the files that were read
+func (l *Loader[T]) loadDir(ctx context.Context, tr *tracer) ([]string, error) {
+	if l.Dir == "" {
+		return nil, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	files, err := l.dirFiles()
+	if err != nil {
+		if l.Options.SkipMissingFiles && errors.Is(err, ErrSourceNotFound) {
+			l.logger().Info("skipped missing env directory", "dir", l.Dir)
+			tr.fileSkipped(l.Dir)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error loading env directory %s: %w", l.Dir, err)
+	}
+
+	for i, file := range files {
+		if err := l.loadEnvFile(ctx, file, tr); err != nil {
+			return files[:i], fmt.Errorf("error loading env file %s: %w", file, err)
+		}
+	}
+
+	return files, nil
+}
+
+// dirFiles returns the *.env files of the configured directory in load order.
+// Since loading never overwrites variables that are already set, files are returned
+// in reverse lexical order so that later-sorted files take precedence.
+func (l *Loader[T]) dirFiles() ([]string, error) {
+	if _, err := os.Stat(l.Dir); os.IsNotExist(err) {
+		return nil, ErrSourceNotFound
+	}
+
+	files, err := filepath.Glob(filepath.Join(l.Dir, "*.env"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list env files: %w", err)
+	}
+
+	if len(files) == 0 {
+		return nil, ErrSourceNotFound
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+
+	return files, nil
+}
+
+// environmentFiles returns the files configured by WithEnvironmentFiles in load order.
+// Since loading never overwrites variables that are already set, the environment-specific
+// file is loaded before the base file so that its values take precedence.
+func (l *Loader[T]) environmentFiles() []string {
+	if l.Options.EnvironmentVar == "" {
+		return nil
+	}
+
+	base := filepath.Join(l.Options.EnvironmentDir, ".env")
+	name := os.Getenv(l.Options.EnvironmentVar)
+	if name == "" {
+		return []string{base}
+	}
+
+	return []string{base + "." + name, base}
+}
+
+// environment builds the variables the parser reads from, applying any configured
+// pre-parse processing such as reference resolution. files are the env files that were loaded.
+func (l *Loader[T]) environment(files []string) (map[string]string, error) {
+	environ := l.rawEnvironment()
+	keys := l.walker().Fields(reflect.TypeFor[T]())
+
+	if l.Options.CaseInsensitive {
+		matchKeysCaseInsensitive(environ, keys)
+	}
+
+	if l.Options.TrimSpace || l.Options.StripQuotes {
+		cleanValues(environ, keys, l.Options.TrimSpace, l.Options.StripQuotes)
+	}
+
+	if l.Options.Strict {
+		if err := l.checkUnknownVariables(environ); err != nil {
+			return nil, err
+		}
 	}
 
-	return &cfg, nil
+	if l.Options.TreatEmptyAsUnset {
+		maps.DeleteFunc(environ, func(_, value string) bool {
+			return value == ""
+		})
+	}
+
+	if l.Options.IndexedSlices {
+		for _, prefix := range indexedSlicePrefixes(reflect.TypeFor[T](), l.walker()) {
+			compactIndices(environ, prefix)
+		}
+	}
+
+	if len(l.Options.ReferenceOrder) > 0 {
+		config, err := l.fileValues(files)
+		if err != nil {
+			return nil, err
+		}
+
+		expandReferences(environ, keys, l.Options.ReferenceOrder, config)
+	}
+
+	if l.Options.ResolveReferences {
+		if err := resolveReferences(environ, keys); err != nil {
+			return nil, fmt.Errorf("error resolving references: %w", err)
+		}
+	}
+
+	if err := decodeValues(environ, keys); err != nil {
+		return nil, fmt.Errorf("error decoding values: %w", err)
+	}
+
+	if err := convertTimeValues(environ, keys, l.Options.TimeLayout); err != nil {
+		return nil, fmt.Errorf("error parsing time values: %w", err)
+	}
+
+	return environ, nil
+}
+
+// rawEnvironment returns a copy of the variables visible to the parser before any processing
+func (l *Loader[T]) rawEnvironment() map[string]string {
+	if environ := l.envOptions().Environment; environ != nil {
+		return maps.Clone(environ)
+	}
+
+	return env.ToMap(os.Environ())
+}
+
+// envOptions returns the options passed to the env parser, combining WithEnvOptions
+// with the loader options that map onto it
+func (l *Loader[T]) envOptions() env.Options {
+	opts := l.Options.EnvOptions
+	opts.FuncMap = withDefaultParsers(opts.FuncMap)
+	maps.Copy(opts.FuncMap, l.Options.Parsers)
+
+	if l.Options.Prefix != "" {
+		opts.Prefix = l.Options.Prefix
+	}
+
+	if l.Options.TagName != "" {
+		opts.TagName = l.Options.TagName
+	}
+
+	if l.Options.Environment != nil {
+		opts.Environment = l.Options.Environment
+	}
+
+	return opts
+}
+
+// walker returns a field walker matching the tag configuration of the env parser
+func (l *Loader[T]) walker() fields.Walker {
+	opts := l.envOptions()
+
+	return fields.Walker{
+		TagName:       opts.TagName,
+		PrefixTagName: opts.PrefixTagName,
+		Prefix:        opts.Prefix,
+	}
+}
+
+// loadEnvFile loads environment variables from a .env file using godotenv.
+// Like godotenv.Load, it never overwrites variables that are already set.
+func (l *Loader[T]) loadEnvFile(ctx context.Context, filename string, tr *tracer) error {
+	values, err := readEnvFile(ctx, filename)
+	if err != nil {
+		return err
+	}
+
+	l.logger().Debug("loaded env file", "file", filename, "keys", len(values))
+	tr.fileLoaded(filename)
+
+	return l.applyValues(filename, values, tr)
 }
 
-// loadEnvFile loads environment variables from a .env file using godotenv
-func (l *Loader[T]) loadEnvFile(filename string) error {
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		return ErrSourceNotFound
+// readEnvFile reads a .env file, returning ctx.Err() if ctx is done before the read completes.
+// A read that is abandoned this way finishes in the background and its result is discarded.
+func readEnvFile(ctx context.Context, filename string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		values map[string]string
+		err    error
 	}
 
-	// Use godotenv to load the file
-	if err := godotenv.Load(filename); err != nil {
-		return fmt.Errorf("failed to load env file: %w", err)
+	done := make(chan result, 1)
+	go func() {
+		info, err := os.Stat(filename)
+		if os.IsNotExist(err) {
+			done <- result{err: ErrSourceNotFound}
+			return
+		}
+
+		if err == nil && info.IsDir() {
+			done <- result{err: ErrNotARegularFile}
+			return
+		}
+
+		values, err := readEnvData(filename)
+		if err != nil {
+			err = fmt.Errorf("failed to load env file: %w", err)
+		}
+
+		done <- result{values: values, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.values, r.err
+	}
+}
+
+// utf8BOM is the byte order mark some editors write at the start of UTF-8 files
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// readEnvData reads and parses a .env file
+func readEnvData(filename string) (map[string]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEnvData(data)
+}
+
+// parseEnvData parses env-file formatted data with godotenv after stripping a leading UTF-8
+// byte order mark, which would otherwise become part of the first key, and normalizing CRLF
+// line endings
+func parseEnvData(data []byte) (map[string]string, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	return godotenv.Parse(bytes.NewReader(data))
+}
+
+// applyValues sets the values read from source in the process environment,
+// leaving variables that are already set untouched
+func (l *Loader[T]) applyValues(source string, values map[string]string, tr *tracer) error {
+	keys := slices.Sorted(maps.Keys(values))
+	for _, key := range keys {
+		if _, exists := os.LookupEnv(key); exists {
+			tr.keyOverridden(source, key)
+			continue
+		}
+
+		if err := os.Setenv(key, values[key]); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+
+		tr.keySet(source, key)
 	}
 
 	return nil
@@ -0,0 +1,71 @@
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLoaderEnvironmentFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		appEnv   string
+		files    map[string]string
+		expected SampleConfig
+	}{
+		{
+			name:   "Environment set with both files",
+			appEnv: "staging",
+			files: map[string]string{
+				".env":         "APP_NAME=base\nPORT=8080\n",
+				".env.staging": "PORT=9090\n",
+			},
+			expected: SampleConfig{AppName: "base", Port: 9090},
+		},
+		{
+			name: "Environment unset loads base only",
+			files: map[string]string{
+				".env":         "APP_NAME=base\nPORT=8080\n",
+				".env.staging": "PORT=9090\n",
+			},
+			expected: SampleConfig{AppName: "base", Port: 8080},
+		},
+		{
+			name:   "Environment file missing",
+			appEnv: "production",
+			files: map[string]string{
+				".env": "APP_NAME=base\nPORT=8080\n",
+			},
+			expected: SampleConfig{AppName: "base", Port: 8080},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			t.Setenv("APP_ENV", tc.appEnv)
+
+			loader, err := env.NewLoader[SampleConfig](nil, env.WithEnvironmentFiles(dir, "APP_ENV"))
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			assertConfigValues(t, cfg, &tc.expected)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// FieldError describes why a single field could not be loaded
+type FieldError struct {
+	// Key is the environment variable the field is read from
+	Key string
+
+	// Field is the dotted Go path of the field, e.g. "Database.Port"
+	Field string
+
+	// Value is the raw string value that failed to parse, if any. It is masked for fields
+	// tagged secret:"true".
+	Value string
+
+	// Type is the Go type the value was parsed into
+	Type reflect.Type
+
+	// Err is the underlying error
+	Err error
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Key, e.Type, e.Err)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// LoadError is returned by Load when parsing environment variables into the struct fails.
+// Use errors.As to access the individual field errors. Raw values of fields tagged
+// secret:"true" are replaced by "****" in the messages of the LoadError and its field errors.
+type LoadError struct {
+	Fields []FieldError
+	Err    error
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("error parsing env variables into struct: %v", e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// secretErrorMask replaces raw secret values in error messages
+const secretErrorMask = "****"
+
+// scrubbedError hides secret values in the message of the wrapped error. The wrapped error
+// stays reachable through errors.Is and errors.As.
+type scrubbedError struct {
+	err     error
+	secrets []string
+}
+
+func (e scrubbedError) Error() string {
+	msg := e.err.Error()
+	for _, secret := range e.secrets {
+		msg = strings.ReplaceAll(msg, secret, secretErrorMask)
+	}
+
+	return msg
+}
+
+func (e scrubbedError) Unwrap() error {
+	return e.err
+}
+
+// secretValues returns the non-empty values of the secret fields in environ
+func secretValues(environ map[string]string, keys []fields.Field) []string {
+	var secrets []string
+	for _, f := range keys {
+		if value := environ[f.Key]; f.Secret() && value != "" {
+			secrets = append(secrets, value)
+		}
+	}
+
+	return secrets
+}
+
+// newLoadError maps the errors reported by the env parser to the fields they belong to,
+// masking the values of secret fields
+func newLoadError(err error, environ map[string]string, keys []fields.Field) *LoadError {
+	secrets := secretValues(environ, keys)
+	if len(secrets) > 0 {
+		err = scrubbedError{err: err, secrets: secrets}
+	}
+
+	loadErr := &LoadError{Err: err}
+
+	var aggregate env.AggregateError
+	if !errors.As(err, &aggregate) {
+		return loadErr
+	}
+
+	for _, e := range aggregate.Errors {
+		if f, ok := fieldForError(e, environ, keys); ok {
+			value := environ[f.Key]
+			if f.Secret() && value != "" {
+				value = secretErrorMask
+			}
+
+			if len(secrets) > 0 {
+				e = scrubbedError{err: e, secrets: secrets}
+			}
+
+			loadErr.Fields = append(loadErr.Fields, FieldError{
+				Key:   f.Key,
+				Field: f.Name,
+				Value: value,
+				Type:  f.Struct.Type,
+				Err:   e,
+			})
+		}
+	}
+
+	return loadErr
+}
+
+// fieldForError finds the field an env parser error refers to
+func fieldForError(err error, environ map[string]string, keys []fields.Field) (fields.Field, bool) {
+	var (
+		parseErr    env.ParseError
+		notSetErr   env.VarIsNotSetError
+		emptyVarErr env.EmptyVarError
+	)
+
+	switch {
+	case errors.As(err, &parseErr):
+		// Parse errors only carry the Go field name, so prefer a same-named field with a value set
+		var candidate *fields.Field
+		for i, f := range keys {
+			if f.Struct.Name != parseErr.Name || f.Struct.Type != parseErr.Type {
+				continue
+			}
+
+			if _, ok := environ[f.Key]; ok {
+				return f, true
+			}
+
+			if candidate == nil {
+				candidate = &keys[i]
+			}
+		}
+
+		if candidate != nil {
+			return *candidate, true
+		}
+	case errors.As(err, &notSetErr):
+		return fieldByKey(keys, notSetErr.Key)
+	case errors.As(err, &emptyVarErr):
+		return fieldByKey(keys, emptyVarErr.Key)
+	}
+
+	return fields.Field{}, false
+}
+
+// fieldByKey finds the field read from the given environment key
+func fieldByKey(keys []fields.Field, key string) (fields.Field, bool) {
+	for _, f := range keys {
+		if f.Key == key {
+			return f, true
+		}
+	}
+
+	return fields.Field{}, false
+}
@@ -0,0 +1,136 @@
+package env_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLoaderReturnsFieldErrors(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=broken\nPORT=notanumber\n")
+
+	loader, err := env.NewLoader[SampleConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	_, err = loader.Load()
+
+	var loadErr *env.LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected *env.LoadError, got %T: %v", err, err)
+	}
+
+	if len(loadErr.Fields) != 1 {
+		t.Fatalf("expected one field error, got %+v", loadErr.Fields)
+	}
+
+	fieldErr := loadErr.Fields[0]
+	if fieldErr.Key != "PORT" {
+		t.Errorf("Key: expected %q, got %q", "PORT", fieldErr.Key)
+	}
+	if fieldErr.Value != "notanumber" {
+		t.Errorf("Value: expected %q, got %q", "notanumber", fieldErr.Value)
+	}
+	if fieldErr.Type != reflect.TypeFor[int]() {
+		t.Errorf("Type: expected int, got %v", fieldErr.Type)
+	}
+}
+
+func TestLoaderReturnsFieldErrorForMissingRequired(t *testing.T) {
+	type RequiredConfig struct {
+		Token string `env:"TOKEN,required"`
+	}
+
+	loader, err := env.NewLoader[RequiredConfig]([]string{"missing.env"}, env.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	_, err = loader.Load()
+
+	var loadErr *env.LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected *env.LoadError, got %T: %v", err, err)
+	}
+
+	if len(loadErr.Fields) != 1 || loadErr.Fields[0].Key != "TOKEN" {
+		t.Fatalf("expected a field error for TOKEN, got %+v", loadErr.Fields)
+	}
+}
+
+func TestLoaderReturnsPartialConfigOnParseError(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=partial\nPORT=notanumber\n")
+
+	loader, err := env.NewLoader[SampleConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+
+	var loadErr *env.LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected *env.LoadError, got %T: %v", err, err)
+	}
+
+	if cfg == nil {
+		t.Fatal("expected a partially populated config, got nil")
+	}
+
+	assertConfigValues(t, cfg, &SampleConfig{AppName: "partial"})
+}
+
+func TestLoaderMasksSecretValuesInErrors(t *testing.T) {
+	type Config struct {
+		APIKey int    `env:"API_KEY" secret:"true"`
+		Region string `env:"REGION"`
+	}
+
+	const secret = "sk-live-5f3a9c"
+
+	loader, err := env.NewLoader[Config](nil, env.WithEnvironment(map[string]string{
+		"API_KEY": secret,
+		"REGION":  "eu-west-1",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	_, err = loader.Load()
+
+	var loadErr *env.LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected *env.LoadError, got %T: %v", err, err)
+	}
+
+	if strings.Contains(err.Error(), secret) {
+		t.Errorf("expected secret to be masked in error, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "****") {
+		t.Errorf("expected mask in error, got %v", err)
+	}
+
+	if len(loadErr.Fields) != 1 {
+		t.Fatalf("expected one field error, got %+v", loadErr.Fields)
+	}
+
+	fieldErr := loadErr.Fields[0]
+	if fieldErr.Value != "****" {
+		t.Errorf("Value: expected masked value, got %q", fieldErr.Value)
+	}
+
+	if strings.Contains(fieldErr.Error(), secret) || strings.Contains(fmt.Sprintf("%+v", loadErr.Fields), secret) {
+		t.Errorf("expected secret to be masked in field error, got %v", fieldErr)
+	}
+}
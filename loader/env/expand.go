@@ -0,0 +1,100 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ReferenceSource identifies where a ${NAME} reference is looked up
+type ReferenceSource int
+
+const (
+	// ReferenceConfig looks up NAME among the values of the loaded env files and data
+	ReferenceConfig ReferenceSource = iota + 1
+
+	// ReferenceEnvironment looks up NAME in the process environment
+	ReferenceEnvironment
+)
+
+// ErrInvalidReferenceOrder indicates that WithReferenceOrder received an empty, unknown or repeated source.
+var ErrInvalidReferenceOrder = errors.New("invalid reference order")
+
+var expandPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// String returns the name of the reference source
+func (s ReferenceSource) String() string {
+	switch s {
+	case ReferenceConfig:
+		return "config"
+	case ReferenceEnvironment:
+		return "environment"
+	default:
+		return fmt.Sprintf("ReferenceSource(%d)", int(s))
+	}
+}
+
+// expandReferences replaces ${NAME} references in the values of the given fields, looking NAME up
+// in the sources in order. References that no source defines are left untouched.
+func expandReferences(environ map[string]string, keys []fields.Field, order []ReferenceSource, config map[string]string) {
+	sources := make([]map[string]string, 0, len(order))
+	for _, source := range order {
+		switch source {
+		case ReferenceConfig:
+			sources = append(sources, config)
+		case ReferenceEnvironment:
+			// Look up the unexpanded values so that references never chain
+			sources = append(sources, maps.Clone(environ))
+		}
+	}
+
+	for _, f := range keys {
+		value, ok := environ[f.Key]
+		if !ok {
+			continue
+		}
+
+		environ[f.Key] = expandPattern.ReplaceAllStringFunc(value, func(ref string) string {
+			name := expandPattern.FindStringSubmatch(ref)[1]
+			for _, source := range sources {
+				if v, ok := source[name]; ok {
+					return v
+				}
+			}
+
+			return ref
+		})
+	}
+}
+
+// fileValues reads the values of the loader's in-memory data and the given env files,
+// earlier sources taking precedence as they do when loading
+func (l *Loader[T]) fileValues(files []string) (map[string]string, error) {
+	values := make(map[string]string)
+	if l.Data != nil {
+		parsed, err := parseEnvData(l.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error reading env data: %w", err)
+		}
+
+		maps.Copy(values, parsed)
+	}
+
+	for _, file := range files {
+		read, err := readEnvData(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading env file %s: %w", file, err)
+		}
+
+		for key, value := range read {
+			if _, exists := values[key]; !exists {
+				values[key] = value
+			}
+		}
+	}
+
+	return values, nil
+}
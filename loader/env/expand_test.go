@@ -0,0 +1,90 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type ReferenceConfig struct {
+	DatabaseURL string `env:"DATABASE_URL"`
+}
+
+func TestLoaderReferenceOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    []env.ReferenceSource
+		expected string
+	}{
+		{
+			name:     "Config before environment",
+			order:    []env.ReferenceSource{env.ReferenceConfig, env.ReferenceEnvironment},
+			expected: "postgres://file-host/app",
+		},
+		{
+			name:     "Environment before config",
+			order:    []env.ReferenceSource{env.ReferenceEnvironment, env.ReferenceConfig},
+			expected: "postgres://process-host/app",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("DB_HOST", "process-host")
+			defer clearEnvironmentVariables("DATABASE_URL")
+
+			file := createTempEnvFile(t, "DB_HOST=file-host\nDATABASE_URL='postgres://${DB_HOST}/app'\n")
+
+			loader, err := env.NewLoader[ReferenceConfig]([]string{file}, env.WithReferenceOrder(tc.order...))
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			if cfg.DatabaseURL != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, cfg.DatabaseURL)
+			}
+		})
+	}
+}
+
+func TestLoaderReferenceOrderUnresolved(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://${UNDEFINED_HOST}/app")
+
+	loader, err := env.NewLoader[ReferenceConfig](nil,
+		env.WithEnvironmentFiles(t.TempDir(), "APP_ENV"),
+		env.WithReferenceOrder(env.ReferenceEnvironment),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://${UNDEFINED_HOST}/app" {
+		t.Errorf("expected unresolved reference to be kept, got %q", cfg.DatabaseURL)
+	}
+}
+
+func TestWithReferenceOrderInvalid(t *testing.T) {
+	orders := [][]env.ReferenceSource{
+		nil,
+		{env.ReferenceConfig, env.ReferenceConfig},
+		{env.ReferenceSource(42)},
+	}
+
+	for _, order := range orders {
+		_, err := env.NewLoader[ReferenceConfig]([]string{".env"}, env.WithReferenceOrder(order...))
+		if !errors.Is(err, env.ErrInvalidReferenceOrder) {
+			t.Errorf("order %v: expected ErrInvalidReferenceOrder, got %v", order, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package env
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// InputHash returns a SHA-256 hash over the in-memory data and the contents of the configured
+// env files (including directory and environment-specific files, in load order) and the current
+// values of every environment key read by T. Keys are hashed in sorted order, so the
+// result does not depend on map iteration.
+func (l *Loader[T]) InputHash() (string, error) {
+	h := sha256.New()
+
+	var dirFiles []string
+	if l.Dir != "" {
+		dirFiles, _ = l.dirFiles()
+	}
+
+	files := make([]string, 0, len(l.Files))
+	for _, name := range l.Files {
+		file, err := expandPath(name)
+		if err != nil {
+			return "", fmt.Errorf("error hashing env file %s: %w", name, err)
+		}
+
+		files = append(files, file)
+	}
+
+	files = slices.Concat(files, dirFiles, l.environmentFiles())
+
+	if l.Data != nil {
+		writeHashField(h, "data")
+		writeHashField(h, string(l.Data))
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			writeHashField(h, "missing:"+file)
+			continue
+		case err != nil:
+			return "", fmt.Errorf("error hashing env file %s: %w", file, err)
+		}
+
+		writeHashField(h, "file:"+file)
+		writeHashField(h, string(data))
+	}
+
+	environ := l.rawEnvironment()
+
+	var keys []string
+	for _, f := range l.walker().Fields(reflect.TypeFor[T]()) {
+		keys = append(keys, f.Key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := environ[key]
+		if !ok {
+			continue
+		}
+
+		writeHashField(h, "env:"+key)
+		writeHashField(h, value)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeHashField writes a length-prefixed field so adjacent fields cannot be confused
+func writeHashField(h hash.Hash, s string) {
+	_ = binary.Write(h, binary.BigEndian, uint64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
@@ -0,0 +1,50 @@
+package env_test
+
+import (
+	"os"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestInputHash(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=hashed\nPORT=8080\n")
+
+	loader, err := env.NewLoader[SampleConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	first := mustInputHash(t, loader)
+	second := mustInputHash(t, loader)
+	if first != second {
+		t.Errorf("expected identical inputs to produce identical hashes, got %s and %s", first, second)
+	}
+
+	if err := os.WriteFile(file, []byte("APP_NAME=hashed\nPORT=9090\n"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite env file: %v", err)
+	}
+
+	if changed := mustInputHash(t, loader); changed == first {
+		t.Error("expected changed file contents to change the hash")
+	}
+
+	t.Setenv("APP_NAME", "other")
+	if changed := mustInputHash(t, loader); changed == first {
+		t.Error("expected changed environment to change the hash")
+	}
+}
+
+func mustInputHash(t *testing.T, loader goconfig.ConfigLoader[SampleConfig]) string {
+	t.Helper()
+
+	hash, err := goconfig.InputHash(loader)
+	if err != nil {
+		t.Fatalf("unexpected error computing input hash: %v", err)
+	}
+
+	return hash
+}
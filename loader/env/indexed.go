@@ -0,0 +1,136 @@
+package env
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// indexedSlice is a slice-of-struct field that the env parser fills from indexed keys of
+// the form <Prefix><index>_<element key>, such as SERVERS_0_HOST
+type indexedSlice struct {
+	// Prefix is the key prefix of the slice, ending in "_"
+	Prefix string
+
+	// Elem is the struct type of the slice elements
+	Elem reflect.Type
+}
+
+// indexedSlicePrefixes returns the key prefixes, ending in "_", of the slice-of-struct fields
+// of t that the env parser fills from indexed keys such as SERVERS_0_HOST.
+// Slices nested inside slice elements are not included.
+func indexedSlicePrefixes(t reflect.Type, walker fields.Walker) []string {
+	var prefixes []string
+	for _, s := range indexedSlices(t, walker) {
+		prefixes = append(prefixes, s.Prefix)
+	}
+
+	return prefixes
+}
+
+// indexedSlices returns the slice-of-struct fields of t filled from indexed keys.
+// Slices nested inside slice elements are not included.
+func indexedSlices(t reflect.Type, walker fields.Walker) []indexedSlice {
+	if walker.TagName == "" {
+		walker.TagName = fields.DefaultTagName
+	}
+
+	if walker.PrefixTagName == "" {
+		walker.PrefixTagName = fields.DefaultPrefixTagName
+	}
+
+	return slicesBelow(t, walker.Prefix, walker.TagName, walker.PrefixTagName)
+}
+
+// slicesBelow collects the indexed slices of t below prefix
+func slicesBelow(t reflect.Type, prefix, tagName, prefixTagName string) []indexedSlice {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var found []indexedSlice
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() || sf.Tag.Get(tagName) != "" {
+			continue
+		}
+
+		fieldPrefix := prefix + sf.Tag.Get(prefixTagName)
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		switch {
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct:
+			if !strings.HasSuffix(fieldPrefix, "_") {
+				fieldPrefix += "_"
+			}
+
+			found = append(found, indexedSlice{Prefix: fieldPrefix, Elem: ft.Elem()})
+		case ft.Kind() == reflect.Struct:
+			found = append(found, slicesBelow(ft, fieldPrefix, tagName, prefixTagName)...)
+		}
+	}
+
+	return found
+}
+
+// compactIndices renumbers the indexed keys under prefix in environ to 0, 1, 2, ... keeping
+// their numeric order, since the env parser stops reading a slice at the first missing index.
+// Indices with leading zeros or signs are not recognized and left untouched.
+func compactIndices(environ map[string]string, prefix string) {
+	found := make(map[int]bool)
+	for key := range environ {
+		if index, _, ok := splitIndexedKey(key, prefix); ok {
+			found[index] = true
+		}
+	}
+
+	indices := slices.Sorted(maps.Keys(found))
+	renumbered := make(map[int]int, len(indices))
+	for i, index := range indices {
+		renumbered[index] = i
+	}
+
+	moved := make(map[string]string)
+	for key, value := range environ {
+		index, rest, ok := splitIndexedKey(key, prefix)
+		if !ok || renumbered[index] == index {
+			continue
+		}
+
+		delete(environ, key)
+		moved[prefix+strconv.Itoa(renumbered[index])+"_"+rest] = value
+	}
+
+	maps.Copy(environ, moved)
+}
+
+// splitIndexedKey splits a key of the form <prefix><index>_<rest> into its index and rest
+func splitIndexedKey(key, prefix string) (int, string, bool) {
+	suffix, ok := strings.CutPrefix(key, prefix)
+	if !ok {
+		return 0, "", false
+	}
+
+	digits, rest, ok := strings.Cut(suffix, "_")
+	if !ok {
+		return 0, "", false
+	}
+
+	index, err := strconv.Atoi(digits)
+	if err != nil || index < 0 || strconv.Itoa(index) != digits {
+		return 0, "", false
+	}
+
+	return index, rest, true
+}
@@ -0,0 +1,68 @@
+package env_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type Server struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT"`
+}
+
+type ClusterConfig struct {
+	Servers []Server `envPrefix:"SERVERS"`
+}
+
+func TestLoaderWithIndexedSlices(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ map[string]string
+		want    []Server
+	}{
+		{
+			name: "Contiguous indices",
+			environ: map[string]string{
+				"SERVERS_0_HOST": "a.internal",
+				"SERVERS_0_PORT": "8080",
+				"SERVERS_1_HOST": "b.internal",
+				"SERVERS_1_PORT": "8081",
+			},
+			want: []Server{{Host: "a.internal", Port: 8080}, {Host: "b.internal", Port: 8081}},
+		},
+		{
+			name: "Sparse indices",
+			environ: map[string]string{
+				"SERVERS_10_HOST": "c.internal",
+				"SERVERS_2_HOST":  "b.internal",
+				"SERVERS_2_PORT":  "8081",
+				"SERVERS_1_HOST":  "a.internal",
+			},
+			want: []Server{{Host: "a.internal"}, {Host: "b.internal", Port: 8081}, {Host: "c.internal"}},
+		},
+		{
+			name:    "No indexed keys",
+			environ: map[string]string{"SERVERS": "ignored"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader, err := env.NewLoader[ClusterConfig](nil, env.WithEnvironment(tc.environ), env.WithIndexedSlices())
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			if !reflect.DeepEqual(cfg.Servers, tc.want) {
+				t.Errorf("expected servers %+v, got %+v", tc.want, cfg.Servers)
+			}
+		})
+	}
+}
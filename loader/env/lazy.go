@@ -0,0 +1,77 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ErrUnknownField indicates that a field path does not name an env-tagged field of the config type.
+var ErrUnknownField = errors.New("unknown config field")
+
+// LazyConfig resolves configuration values from the environment on every access
+// instead of once at load time, so long-running processes observe variables that
+// change in the process environment after startup, e.g. through os.Setenv.
+//
+// Changes to env files are not observed: the first Load copies their values into the
+// process environment, and later loads never override variables that are already set.
+// Every access runs a full Load, which re-reads env files and re-parses the whole
+// struct; prefer a regular Load for values read on hot paths. Since each access is
+// resolved independently, two accessors read one after the other may observe
+// different versions of the environment.
+type LazyConfig[T any] struct {
+	loader *Loader[T]
+}
+
+// NewLazyConfig returns a LazyConfig that resolves values with loader
+func NewLazyConfig[T any](loader *Loader[T]) *LazyConfig[T] {
+	return &LazyConfig[T]{loader: loader}
+}
+
+// Get resolves the whole configuration from the current environment
+func (c *LazyConfig[T]) Get() (*T, error) {
+	return c.loader.Load()
+}
+
+// LazyField returns an accessor for the field at the dotted Go path name (e.g. "Database.Host")
+// that resolves its value from the current environment on every call.
+// It fails if name is not an env-tagged field of T or if the field is not of type V.
+func LazyField[T, V any](c *LazyConfig[T], name string) (func() (V, error), error) {
+	var (
+		field fields.Field
+		found bool
+	)
+
+	for _, f := range c.loader.walker().Fields(reflect.TypeFor[T]()) {
+		if f.Name == name {
+			field, found = f, true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w %q", ErrUnknownField, name)
+	}
+
+	if want := reflect.TypeFor[V](); field.Struct.Type != want {
+		return nil, fmt.Errorf("field %s is of type %s, not %s", name, field.Struct.Type, want)
+	}
+
+	return func() (V, error) {
+		var zero V
+
+		cfg, err := c.loader.Load()
+		if err != nil {
+			return zero, err
+		}
+
+		v, ok := fields.Value(reflect.ValueOf(cfg), field.Index)
+		if !ok {
+			return zero, nil
+		}
+
+		return v.Interface().(V), nil
+	}, nil
+}
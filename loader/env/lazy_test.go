@@ -0,0 +1,94 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLazyFieldReflectsChangedEnvironment(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=lazy\nPORT=8080\n")
+
+	loader, err := env.NewLoader[SampleConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	port, err := env.LazyField[SampleConfig, int](env.NewLazyConfig(loader), "Port")
+	if err != nil {
+		t.Fatalf("failed to create accessor: %v", err)
+	}
+
+	got, err := port()
+	if err != nil {
+		t.Fatalf("unexpected error reading port: %v", err)
+	}
+	if got != 8080 {
+		t.Errorf("expected port 8080, got %d", got)
+	}
+
+	if err := os.Setenv("PORT", "9090"); err != nil {
+		t.Fatalf("failed to set PORT: %v", err)
+	}
+
+	got, err = port()
+	if err != nil {
+		t.Fatalf("unexpected error reading port: %v", err)
+	}
+	if got != 9090 {
+		t.Errorf("expected port 9090 after change, got %d", got)
+	}
+}
+
+func TestLazyFieldIgnoresChangedEnvFile(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=lazy\nPORT=8080\n")
+
+	loader, err := env.NewLoader[SampleConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	port, err := env.LazyField[SampleConfig, int](env.NewLazyConfig(loader), "Port")
+	if err != nil {
+		t.Fatalf("failed to create accessor: %v", err)
+	}
+
+	if _, err := port(); err != nil {
+		t.Fatalf("unexpected error reading port: %v", err)
+	}
+
+	if err := os.WriteFile(file, []byte("APP_NAME=lazy\nPORT=9090\n"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite env file: %v", err)
+	}
+
+	got, err := port()
+	if err != nil {
+		t.Fatalf("unexpected error reading port: %v", err)
+	}
+	if got != 8080 {
+		t.Errorf("expected the first value from the env file to stick, got %d", got)
+	}
+}
+
+func TestLazyFieldInvalid(t *testing.T) {
+	loader, err := env.NewLoader[SampleConfig]([]string{"unused.env"})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	lazy := env.NewLazyConfig(loader)
+
+	if _, err := env.LazyField[SampleConfig, int](lazy, "Missing"); !errors.Is(err, env.ErrUnknownField) {
+		t.Errorf("expected ErrUnknownField, got %v", err)
+	}
+
+	if _, err := env.LazyField[SampleConfig, string](lazy, "Port"); err == nil {
+		t.Error("expected type mismatch error, got nil")
+	}
+}
@@ -0,0 +1,25 @@
+package env
+
+// Logger receives diagnostic messages from the loader. Arguments are alternating key-value
+// pairs, so *slog.Logger satisfies the interface directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+}
+
+// nopLogger discards all messages
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+
+// logger returns the configured logger, or a no-op logger if none is set
+func (l *Loader[T]) logger() Logger {
+	if l.Options.Logger == nil {
+		return nopLogger{}
+	}
+
+	return l.Options.Logger
+}
@@ -0,0 +1,71 @@
+package env_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+// capturingLogger records every message as "LEVEL msg key=value ..."
+type capturingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (c *capturingLogger) Debug(msg string, args ...any) { c.record("DEBUG", msg, args) }
+func (c *capturingLogger) Info(msg string, args ...any)  { c.record("INFO", msg, args) }
+func (c *capturingLogger) Warn(msg string, args ...any)  { c.record("WARN", msg, args) }
+
+func (c *capturingLogger) record(level, msg string, args []any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	line := level + " " + msg
+	for i := 0; i+1 < len(args); i += 2 {
+		line += fmt.Sprintf(" %v=%v", args[i], args[i+1])
+	}
+
+	c.messages = append(c.messages, line)
+}
+
+func (c *capturingLogger) contains(substr string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, m := range c.messages {
+		if strings.Contains(m, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestLoaderLogsSkippedFiles(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=logged\nPORT=8080\n")
+	logger := &capturingLogger{}
+
+	loader, err := env.NewLoader[SampleConfig]([]string{file, "missing.env"},
+		env.WithSkipMissingFiles(),
+		env.WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if !logger.contains("INFO skipped missing env file file=missing.env") {
+		t.Errorf("expected skip message, got %v", logger.messages)
+	}
+	if !logger.contains("DEBUG loaded env file file=" + file) {
+		t.Errorf("expected load message for %s, got %v", file, logger.messages)
+	}
+}
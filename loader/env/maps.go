@@ -0,0 +1,138 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ErrMapKeyCollision indicates that two map keys became identical after normalization.
+var ErrMapKeyCollision = errors.New("map key collision")
+
+// lowerMapKeys lowercases the keys of every map[string]string field in cfg
+func lowerMapKeys(cfg any, walker fields.Walker) error {
+	root := reflect.ValueOf(cfg)
+
+	var errs []error
+	walker.Walk(root.Type(), func(f fields.Field) {
+		v, ok := fields.Value(root, f.Index)
+		if !ok || !isStringMap(v.Type()) || v.IsNil() {
+			return
+		}
+
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+
+		lowered := reflect.MakeMapWithSize(v.Type(), v.Len())
+		seen := make(map[string]string, len(keys))
+		for _, k := range keys {
+			lower := strings.ToLower(k)
+			if prev, exists := seen[lower]; exists {
+				errs = append(errs, fmt.Errorf("%w: %s: keys %q and %q", ErrMapKeyCollision, f.Key, prev, k))
+				continue
+			}
+
+			seen[lower] = k
+			lowered.SetMapIndex(reflect.ValueOf(lower).Convert(v.Type().Key()), v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())))
+		}
+
+		v.Set(lowered)
+	})
+
+	return errors.Join(errs...)
+}
+
+// isStringMap reports whether t is a map with string keys and string values
+func isStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.String
+}
+
+// isCollectedMap reports whether f is a map field that collects the variables under its key,
+// i.e. a map with string keys whose key ends in "_"
+func isCollectedMap(f fields.Field) bool {
+	t := f.Struct.Type
+	return strings.HasSuffix(f.Key, "_") && t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// collectMaps fills every collected map field of cfg from the variables in environ starting with
+// the field's key, using the lowercased rest of the variable name as map key
+func collectMaps(cfg any, walker fields.Walker, environ map[string]string) error {
+	root := reflect.ValueOf(cfg)
+
+	var errs []error
+	walker.Walk(root.Type(), func(f fields.Field) {
+		if !isCollectedMap(f) {
+			return
+		}
+
+		v, ok := fields.Value(root, f.Index)
+		if !ok {
+			return
+		}
+
+		var names []string
+		for name := range environ {
+			if len(name) > len(f.Key) && strings.HasPrefix(name, f.Key) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		collected := reflect.MakeMapWithSize(v.Type(), len(names))
+		seen := make(map[string]string, len(names))
+		for _, name := range names {
+			key := strings.ToLower(strings.TrimPrefix(name, f.Key))
+			if prev, exists := seen[key]; exists {
+				errs = append(errs, fmt.Errorf("%w: %s: variables %s and %s", ErrMapKeyCollision, f.Key, prev, name))
+				continue
+			}
+			seen[key] = name
+
+			value, err := parseCollectedValue(v.Type().Elem(), environ[name])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+
+			collected.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), value)
+		}
+
+		v.Set(collected)
+	})
+
+	return errors.Join(errs...)
+}
+
+// parseCollectedValue parses a collected map value of type t, which must be a string, bool or int kind
+func parseCollectedValue(t reflect.Type, raw string) (reflect.Value, error) {
+	value := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return value, fmt.Errorf("invalid bool %q", raw)
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, t.Bits())
+		if err != nil {
+			return value, fmt.Errorf("invalid int %q", raw)
+		}
+		value.SetInt(i)
+	default:
+		return value, fmt.Errorf("unsupported collected map value type %s", t)
+	}
+
+	return value, nil
+}
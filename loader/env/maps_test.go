@@ -0,0 +1,106 @@
+package env_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type LabelsConfig struct {
+	Labels map[string]string `env:"LABELS"`
+}
+
+func TestLoaderLowerMapKeys(t *testing.T) {
+	defer clearEnvironmentVariables("LABELS")
+
+	file := createTempEnvFile(t, "LABELS=Team:core,X-Env:prod")
+
+	loader, err := env.NewLoader[LabelsConfig]([]string{file}, env.WithLowerMapKeys())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	want := map[string]string{"team": "core", "x-env": "prod"}
+	if len(cfg.Labels) != len(want) {
+		t.Fatalf("Labels: expected %v, got %v", want, cfg.Labels)
+	}
+	for k, v := range want {
+		if cfg.Labels[k] != v {
+			t.Errorf("Labels[%q]: expected %q, got %q", k, v, cfg.Labels[k])
+		}
+	}
+}
+
+func TestLoaderLowerMapKeysCollision(t *testing.T) {
+	defer clearEnvironmentVariables("LABELS")
+
+	file := createTempEnvFile(t, "LABELS=Team:core,team:platform")
+
+	loader, err := env.NewLoader[LabelsConfig]([]string{file}, env.WithLowerMapKeys())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	_, err = loader.Load()
+	if !errors.Is(err, env.ErrMapKeyCollision) {
+		t.Fatalf("expected ErrMapKeyCollision, got %v", err)
+	}
+}
+
+type FeatureConfig struct {
+	Flags  map[string]bool `env:"FEATURE_FLAGS_"`
+	Limits map[string]int  `env:"LIMIT_"`
+}
+
+func TestLoaderWithMapCollection(t *testing.T) {
+	loader, err := env.NewLoader[FeatureConfig](nil,
+		env.WithEnvironment(map[string]string{
+			"APP_FEATURE_FLAGS_NEW_UI": "true",
+			"APP_FEATURE_FLAGS_BETA":   "false",
+			"APP_FEATURE_FLAGS_DARK":   "1",
+			"APP_LIMIT_REQUESTS":       "100",
+		}),
+		env.WithEnvPrefix("APP_"),
+		env.WithMapCollection(),
+		env.WithStrict(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	wantFlags := map[string]bool{"new_ui": true, "beta": false, "dark": true}
+	if !reflect.DeepEqual(cfg.Flags, wantFlags) {
+		t.Errorf("Flags: expected %v, got %v", wantFlags, cfg.Flags)
+	}
+
+	wantLimits := map[string]int{"requests": 100}
+	if !reflect.DeepEqual(cfg.Limits, wantLimits) {
+		t.Errorf("Limits: expected %v, got %v", wantLimits, cfg.Limits)
+	}
+}
+
+func TestLoaderWithMapCollectionInvalidValue(t *testing.T) {
+	loader, err := env.NewLoader[FeatureConfig](nil,
+		env.WithEnvironment(map[string]string{"FEATURE_FLAGS_X": "maybe"}),
+		env.WithMapCollection(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err == nil {
+		t.Fatal("expected an error for an invalid bool value")
+	}
+}
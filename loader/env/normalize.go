@@ -0,0 +1,63 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// normalizeFields applies the rules listed in the normalize tag of every field in cfg
+func normalizeFields(cfg any, walker fields.Walker) error {
+	root := reflect.ValueOf(cfg)
+
+	var err error
+	walker.Walk(root.Type(), func(f fields.Field) {
+		if err != nil {
+			return
+		}
+
+		v, ok := fields.Value(root, f.Index)
+		if !ok {
+			return
+		}
+
+		err = normalizeField(f, v)
+	})
+
+	return err
+}
+
+// normalizeField applies the normalize rules of a single string field in order
+func normalizeField(f fields.Field, v reflect.Value) error {
+	tag, ok := f.Struct.Tag.Lookup("normalize")
+	if !ok {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("%s: normalize requires a string field, got %s", f.Key, f.Struct.Type)
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		switch rule = strings.TrimSpace(rule); rule {
+		case "lowercase":
+			v.SetString(strings.ToLower(v.String()))
+		case "trimTrailingSlash":
+			v.SetString(strings.TrimRight(v.String(), "/"))
+		default:
+			return fmt.Errorf("%s: unknown normalize rule %q", f.Key, rule)
+		}
+	}
+
+	return nil
+}
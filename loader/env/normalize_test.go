@@ -0,0 +1,129 @@
+package env_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type NormalizedConfig struct {
+	BaseURL     string  `env:"BASE_URL" normalize:"trimTrailingSlash"`
+	Environment string  `env:"ENVIRONMENT" normalize:"lowercase"`
+	CallbackURL *string `env:"CALLBACK_URL" normalize:"trimTrailingSlash,lowercase"`
+}
+
+func TestLoaderNormalizeTags(t *testing.T) {
+	loader, err := env.NewLoader[NormalizedConfig](nil, env.WithEnvironment(map[string]string{
+		"BASE_URL":     "https://api.example.com/v1/",
+		"ENVIRONMENT":  "Production",
+		"CALLBACK_URL": "HTTPS://Example.com/Hook//",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if want := "https://api.example.com/v1"; cfg.BaseURL != want {
+		t.Errorf("BaseURL: expected %q, got %q", want, cfg.BaseURL)
+	}
+	if want := "production"; cfg.Environment != want {
+		t.Errorf("Environment: expected %q, got %q", want, cfg.Environment)
+	}
+	if want := "https://example.com/hook"; cfg.CallbackURL == nil || *cfg.CallbackURL != want {
+		t.Errorf("CallbackURL: expected %q, got %v", want, cfg.CallbackURL)
+	}
+}
+
+func TestLoaderWithNormalizer(t *testing.T) {
+	type PoolConfig struct {
+		PoolSize int      `env:"POOL_SIZE"`
+		Hosts    []string `env:"HOSTS" minItems:"1"`
+	}
+
+	loader, err := env.NewLoader[PoolConfig](nil,
+		env.WithEnvironment(map[string]string{"POOL_SIZE": "500"}),
+		env.WithNormalizer(func(cfg *PoolConfig) {
+			cfg.PoolSize = min(cfg.PoolSize, 100)
+		}),
+		env.WithNormalizer(func(cfg *PoolConfig) {
+			if len(cfg.Hosts) == 0 {
+				cfg.Hosts = []string{"localhost"}
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("expected normalizers to run before constraints are checked, got %v", err)
+	}
+
+	if cfg.PoolSize != 100 {
+		t.Errorf("PoolSize: expected %d, got %d", 100, cfg.PoolSize)
+	}
+	if len(cfg.Hosts) != 1 || cfg.Hosts[0] != "localhost" {
+		t.Errorf("Hosts: expected [localhost], got %v", cfg.Hosts)
+	}
+}
+
+func TestLoaderNormalizeErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		load          func() error
+		errorContains string
+	}{
+		{
+			name: "Unknown rule",
+			load: func() error {
+				type Config struct {
+					Name string `env:"NAME" normalize:"uppercase"`
+				}
+				return loadWithEnvironment[Config](map[string]string{"NAME": "x"})
+			},
+			errorContains: `unknown normalize rule "uppercase"`,
+		},
+		{
+			name: "Non-string field",
+			load: func() error {
+				type Config struct {
+					Port int `env:"PORT" normalize:"lowercase"`
+				}
+				return loadWithEnvironment[Config](map[string]string{"PORT": "80"})
+			},
+			errorContains: "normalize requires a string field",
+		},
+		{
+			name: "Normalizer for another type",
+			load: func() error {
+				return loadWithEnvironment[SampleConfig](map[string]string{}, env.WithNormalizer(func(*NormalizedConfig) {}))
+			},
+			errorContains: "normalizer expects",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.load()
+			if err == nil || !strings.Contains(err.Error(), tc.errorContains) {
+				t.Errorf("expected error containing %q, got %v", tc.errorContains, err)
+			}
+		})
+	}
+}
+
+func loadWithEnvironment[T any](environ map[string]string, opts ...env.Option) error {
+	loader, err := env.NewLoader[T](nil, append(opts, env.WithEnvironment(environ))...)
+	if err != nil {
+		return err
+	}
+
+	_, err = loader.Load()
+	return err
+}
@@ -0,0 +1,21 @@
+package env
+
+import "time"
+
+// loaderName identifies this loader in observer events
+const loaderName = "env"
+
+// ObserveEvent describes a completed Load call
+type ObserveEvent struct {
+	// Loader is the name of the loader that produced the event, "env" for this package
+	Loader string
+
+	// Duration is the wall time the Load call took
+	Duration time.Duration
+
+	// Files is the number of env files that were read
+	Files int
+
+	// Err is the error returned by Load, or nil on success
+	Err error
+}
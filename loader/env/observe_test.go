@@ -0,0 +1,76 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLoaderObserverOnParseFailure(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=observed\nPORT=not-a-number\n")
+
+	var events []env.ObserveEvent
+	loader, err := env.NewLoader[SampleConfig]([]string{file},
+		env.WithObserver(func(e env.ObserveEvent) {
+			events = append(events, e)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	_, loadErr := loader.Load()
+	if loadErr == nil {
+		t.Fatal("expected parse error, got nil")
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected exactly one observer event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.Loader != "env" {
+		t.Errorf("expected loader name %q, got %q", "env", event.Loader)
+	}
+	if event.Duration <= 0 {
+		t.Errorf("expected non-zero duration, got %v", event.Duration)
+	}
+	if event.Files != 1 {
+		t.Errorf("expected 1 file, got %d", event.Files)
+	}
+
+	var loadError *env.LoadError
+	if !errors.As(event.Err, &loadError) || event.Err != loadErr {
+		t.Errorf("expected observer to receive the Load error %v, got %v", loadErr, event.Err)
+	}
+}
+
+func TestLoaderObserverOnSuccess(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=observed\nPORT=8080\n")
+
+	calls := 0
+	loader, err := env.NewLoader[SampleConfig]([]string{file},
+		env.WithObserver(func(e env.ObserveEvent) {
+			calls++
+			if e.Err != nil {
+				t.Errorf("expected nil error, got %v", e.Err)
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected observer to be called once, got %d", calls)
+	}
+}
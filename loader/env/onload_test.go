@@ -0,0 +1,78 @@
+package env_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type DSNConfig struct {
+	Host string `env:"DB_HOST"`
+	Port int    `env:"DB_PORT"`
+	User string `env:"DB_USER"`
+	DSN  string
+}
+
+func TestLoaderWithOnLoad(t *testing.T) {
+	var order []string
+
+	loader, err := env.NewLoader[DSNConfig](nil,
+		env.WithEnvironment(map[string]string{"DB_HOST": "db.internal", "DB_PORT": "5432", "DB_USER": "app"}),
+		env.WithOnLoad(func(cfg *DSNConfig) error {
+			order = append(order, "dsn")
+			cfg.DSN = fmt.Sprintf("postgres://%s@%s:%d", cfg.User, cfg.Host, cfg.Port)
+			return nil
+		}),
+		env.WithOnLoad(func(cfg *DSNConfig) error {
+			order = append(order, "check")
+			if cfg.DSN == "" {
+				return errors.New("DSN not derived yet")
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if want := "postgres://app@db.internal:5432"; cfg.DSN != want {
+		t.Errorf("expected DSN %q, got %q", want, cfg.DSN)
+	}
+
+	if len(order) != 2 || order[0] != "dsn" || order[1] != "check" {
+		t.Errorf("expected callbacks in registration order, got %v", order)
+	}
+}
+
+func TestLoaderWithOnLoadError(t *testing.T) {
+	errMissingUser := errors.New("DB_USER is required when DB_HOST is set")
+
+	loader, err := env.NewLoader[DSNConfig](nil,
+		env.WithEnvironment(map[string]string{"DB_HOST": "db.internal"}),
+		env.WithOnLoad(func(cfg *DSNConfig) error {
+			if cfg.Host != "" && cfg.User == "" {
+				return errMissingUser
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if !errors.Is(err, errMissingUser) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+
+	if cfg != nil {
+		t.Errorf("expected nil config, got %+v", cfg)
+	}
+}
@@ -1,16 +1,78 @@
 package env
 
-import "github.com/caarlos0/env/v11"
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+
+	"github.com/caarlos0/env/v11"
+)
 
 // Options defines a set of functional options for the environment loader
 type Options struct {
-	SkipMissingFiles bool
-	EnvOptions       env.Options
+	SkipMissingFiles  bool
+	RequiredFiles     []string
+	RequireAnyFile    bool
+	FileOrder         FileOrder
+	LowerMapKeys      bool
+	TrimSpace         bool
+	StripQuotes       bool
+	TreatEmptyAsUnset bool
+	MapCollection     bool
+	SortSlices        bool
+	IndexedSlices     bool
+	ResolveReferences bool
+	ReferenceOrder    []ReferenceSource
+	EnvironmentDir    string
+	EnvironmentVar    string
+	Environment       map[string]string
+	Prefix            string
+	ListSeparator     string
+	TimeLayout        string
+	TagName           string
+	Parsers           map[reflect.Type]env.ParserFunc
+	Trace             *[]TraceStep
+	Logger            Logger
+	SecretAccessLog   *slog.Logger
+	Observer          func(ObserveEvent)
+	Warnings          func(message string)
+	Normalizers       []func(cfg any) error
+	OnLoad            []func(cfg any) error
+	Strict            bool
+	CaseInsensitive   bool
+	EnvOptions        env.Options
 }
 
 // Option defines a functional option for the environment loader
 type Option func(*Options) error
 
+// FileOrder describes how the env files passed to NewLoader are ordered by precedence
+type FileOrder int
+
+const (
+	// HighestFirst lists files from highest to lowest precedence, so the first file that sets
+	// a variable wins. It is the default.
+	HighestFirst FileOrder = iota + 1
+
+	// LowestFirst lists files from lowest to highest precedence, so the last file that sets
+	// a variable wins, e.g. []string{".env", ".env.local"} lets .env.local override .env.
+	LowestFirst
+)
+
+// String returns the name of the file order
+func (o FileOrder) String() string {
+	switch o {
+	case HighestFirst:
+		return "highest-first"
+	case LowestFirst:
+		return "lowest-first"
+	default:
+		return fmt.Sprintf("FileOrder(%d)", int(o))
+	}
+}
+
 // WithSkipMissingFiles configures the loader to skip missing .env files
 func WithSkipMissingFiles() Option {
 	return func(opts *Options) error {
@@ -19,6 +81,40 @@ func WithSkipMissingFiles() Option {
 	}
 }
 
+// WithRequiredFiles marks files that must exist even when WithSkipMissingFiles is set,
+// so that a loader can mix optional and mandatory files. Every required file must also
+// be passed to NewLoader; otherwise NewLoader returns ErrRequiredFileNotListed.
+func WithRequiredFiles(files ...string) Option {
+	return func(opts *Options) error {
+		opts.RequiredFiles = append(opts.RequiredFiles, files...)
+		return nil
+	}
+}
+
+// WithFileLoadOrder sets how the files passed to NewLoader are ordered by precedence.
+// Files never override variables already set in the process environment, whatever the order,
+// and files added through WithEnvironmentFiles or NewDirLoader are not affected.
+func WithFileLoadOrder(order FileOrder) Option {
+	return func(opts *Options) error {
+		if order != HighestFirst && order != LowestFirst {
+			return fmt.Errorf("unknown file load order %s", order)
+		}
+
+		opts.FileOrder = order
+		return nil
+	}
+}
+
+// WithRequireAnyFile makes Load fail with ErrSourceNotFound when none of the files passed to
+// NewLoader exists, so that WithSkipMissingFiles cannot silently yield an all-defaults config
+// because every path has a typo. Individual files may still be missing.
+func WithRequireAnyFile() Option {
+	return func(opts *Options) error {
+		opts.RequireAnyFile = true
+		return nil
+	}
+}
+
 // WithEnvOptions allows passing through options to the underlying env parser
 func WithEnvOptions(envOptions env.Options) Option {
 	return func(opts *Options) error {
@@ -26,3 +122,354 @@ func WithEnvOptions(envOptions env.Options) Option {
 		return nil
 	}
 }
+
+// WithTrimSpace trims surrounding whitespace from the values of keys read by the configuration
+// type before parsing, so PORT=" 8080 " parses as 8080.
+func WithTrimSpace() Option {
+	return func(opts *Options) error {
+		opts.TrimSpace = true
+		return nil
+	}
+}
+
+// WithStripQuotes removes one pair of matching surrounding quotes, single or double, from the
+// values of keys read by the configuration type before parsing, e.g. a process variable set to
+// "8080" including the quotes. Unmatched and inner quotes are kept.
+func WithStripQuotes() Option {
+	return func(opts *Options) error {
+		opts.StripQuotes = true
+		return nil
+	}
+}
+
+// WithTreatEmptyAsUnset removes variables set to the empty string before parsing, so that
+// they behave exactly like unset ones. The env parser already falls back to envDefault for
+// empty values; with this option, an empty variable for a required field also fails the load
+// as missing instead of passing as an explicit empty value. Values emptied by WithTrimSpace
+// count as empty. With WithStrict, empty unknown variables are still reported.
+func WithTreatEmptyAsUnset() Option {
+	return func(opts *Options) error {
+		opts.TreatEmptyAsUnset = true
+		return nil
+	}
+}
+
+// WithLowerMapKeys normalizes the keys of map[string]string fields to lowercase after parsing.
+// Keys that collide after normalization (e.g. "Foo" and "foo") cause Load to fail.
+func WithLowerMapKeys() Option {
+	return func(opts *Options) error {
+		opts.LowerMapKeys = true
+		return nil
+	}
+}
+
+// WithIndexedSlices fills slice-of-struct fields from indexed keys even when the indices are
+// sparse or do not start at zero. Given a field tagged envPrefix:"SERVERS", the keys
+// SERVERS_1_HOST and SERVERS_5_HOST produce a two-element slice in index order, whereas the
+// env parser alone stops at the first missing index. Slices nested inside slice elements are
+// read as usual.
+func WithIndexedSlices() Option {
+	return func(opts *Options) error {
+		opts.IndexedSlices = true
+		return nil
+	}
+}
+
+// WithSortedSlices sorts slice-of-struct fields tagged sortBy:"Field" by the named field after
+// parsing, so that their order does not depend on how the indexed keys were provided.
+// See goconfig.SortSlices.
+func WithSortedSlices() Option {
+	return func(opts *Options) error {
+		opts.SortSlices = true
+		return nil
+	}
+}
+
+// WithMapCollection fills map fields whose key ends in "_" with every variable under that key.
+// A map[string]bool tagged env:"FEATURE_FLAGS_" collects FEATURE_FLAGS_X=true as "x": true, the
+// rest of the name lowercased. Map values must be strings, bools or ints. With WithStrict,
+// collected variables count as known.
+func WithMapCollection() Option {
+	return func(opts *Options) error {
+		opts.MapCollection = true
+		return nil
+	}
+}
+
+// WithReferenceResolution resolves values of the form scheme://path#field through the
+// resolver registered for the scheme (see RegisterResolver) before parsing.
+// Only keys read by the configuration type are considered, and values whose scheme has no
+// registered resolver are left untouched.
+func WithReferenceResolution() Option {
+	return func(opts *Options) error {
+		opts.ResolveReferences = true
+		return nil
+	}
+}
+
+// WithReferenceOrder expands ${NAME} references in the values of keys read by the configuration
+// type, looking NAME up in the given sources in order: ReferenceConfig for values of the loaded
+// env files, ReferenceEnvironment for the process environment. References no source defines are
+// left untouched and expansion is not recursive.
+//
+// godotenv already expands references in unquoted and double-quoted file values against earlier
+// keys of the same file, so file values meant for this expansion must be single-quoted.
+func WithReferenceOrder(order ...ReferenceSource) Option {
+	return func(opts *Options) error {
+		if len(order) == 0 {
+			return fmt.Errorf("%w: no sources given", ErrInvalidReferenceOrder)
+		}
+
+		seen := make(map[ReferenceSource]bool, len(order))
+		for _, source := range order {
+			if source != ReferenceConfig && source != ReferenceEnvironment {
+				return fmt.Errorf("%w: unknown source %s", ErrInvalidReferenceOrder, source)
+			}
+
+			if seen[source] {
+				return fmt.Errorf("%w: source %s given twice", ErrInvalidReferenceOrder, source)
+			}
+			seen[source] = true
+		}
+
+		opts.ReferenceOrder = order
+		return nil
+	}
+}
+
+// WithEnvironmentFiles loads environment-specific files from baseDir after the files passed
+// to NewLoader. Given envVar=APP_ENV and APP_ENV=staging, it loads baseDir/.env and
+// baseDir/.env.staging, skipping either file if it does not exist. When envVar is unset,
+// only baseDir/.env is loaded. The variable is read after the files passed to NewLoader
+// are loaded, so it may be defined in one of them.
+//
+// Precedence, from highest to lowest: variables already set in the process environment,
+// files passed to NewLoader, .env.{APP_ENV}, .env.
+func WithEnvironmentFiles(baseDir, envVar string) Option {
+	return func(opts *Options) error {
+		if envVar == "" {
+			return errors.New("environment variable name is empty")
+		}
+
+		opts.EnvironmentDir = baseDir
+		opts.EnvironmentVar = envVar
+		return nil
+	}
+}
+
+// WithEnvironment makes the loader read variables from environ instead of the process
+// environment. Env files, data and directories are not loaded and the process environment
+// is neither read nor modified, so loaders with different maps can run in parallel tests.
+// It takes precedence over an environment passed through WithEnvOptions.
+func WithEnvironment(environ map[string]string) Option {
+	return func(opts *Options) error {
+		if environ == nil {
+			return errors.New("environment is nil")
+		}
+
+		opts.Environment = environ
+		return nil
+	}
+}
+
+// WithEnvPrefix sets a prefix prepended to every environment key, e.g. "APP_" reads PORT from APP_PORT.
+// envPrefix tags of nested structs are appended after it, outermost first, so a Host field
+// tagged env:"HOST" in a struct tagged envPrefix:"DB_" reads APP_DB_HOST.
+// It takes precedence over a prefix passed through WithEnvOptions.
+func WithEnvPrefix(prefix string) Option {
+	return func(opts *Options) error {
+		opts.Prefix = prefix
+		return nil
+	}
+}
+
+// WithStrict makes Load fail when an environment variable starting with the configured
+// prefix does not correspond to any field, catching typos such as APP_PROT for APP_PORT.
+// Element keys of slice-of-struct fields, such as APP_SERVERS_0_HOST, are accepted.
+// A prefix must be configured, either with WithEnvPrefix or WithEnvOptions.
+func WithStrict() Option {
+	return func(opts *Options) error {
+		opts.Strict = true
+		return nil
+	}
+}
+
+// WithCaseInsensitiveKeys matches environment variables to field keys regardless of case,
+// so port and Port both set a field tagged env:"PORT". A variable matching the key exactly
+// always wins; among several other case variants, the first in byte order is used
+// (uppercase letters sort before lowercase ones, so Port wins over port).
+func WithCaseInsensitiveKeys() Option {
+	return func(opts *Options) error {
+		opts.CaseInsensitive = true
+		return nil
+	}
+}
+
+// WithSchemaVersion reads environment keys from the tag of the given schema version,
+// e.g. env_v2:"NEW_NAME" for version 2, so one struct can serve several deployment
+// generations during a migration. Every field read under a version must carry that
+// version's tag. It is a shorthand for WithTagName("env_v<version>").
+func WithSchemaVersion(version int) Option {
+	return func(opts *Options) error {
+		if version < 1 {
+			return fmt.Errorf("schema version must be positive, got %d", version)
+		}
+
+		opts.TagName = fmt.Sprintf("env_v%d", version)
+		return nil
+	}
+}
+
+// WithListSeparator splits the values of all slice and map fields on sep instead of a comma,
+// e.g. ":" for PATH-like values such as DIRS=/a:/b:/c. While it is set, envSeparator tags are
+// ignored. Map items keep the key:value form, so maps need a separator other than ":".
+// []byte fields and types with a parser registered through WithParsers are not affected.
+func WithListSeparator(sep string) Option {
+	return func(opts *Options) error {
+		if sep == "" {
+			return errors.New("list separator is empty")
+		}
+
+		opts.ListSeparator = sep
+		return nil
+	}
+}
+
+// WithTimeLayout parses time.Time fields with layout, e.g. "2006-01-02" for date-only values,
+// instead of RFC 3339. A timeLayout tag on a field takes precedence, so one field can use
+// timeLayout:"15:04" while the rest use the loader's layout.
+func WithTimeLayout(layout string) Option {
+	return func(opts *Options) error {
+		if layout == "" {
+			return errors.New("time layout is empty")
+		}
+
+		opts.TimeLayout = layout
+		return nil
+	}
+}
+
+// WithTagName reads environment keys from a custom struct tag instead of env, e.g. config:"PORT".
+// It takes precedence over a tag name passed through WithEnvOptions.
+func WithTagName(name string) Option {
+	return func(opts *Options) error {
+		if name == "" {
+			return errors.New("tag name is empty")
+		}
+
+		opts.TagName = name
+		return nil
+	}
+}
+
+// WithParsers registers parser functions for field types the env parser cannot handle natively,
+// such as net.IP wrappers, UUIDs or custom enums. Each parser receives the raw string value and
+// returns the parsed value, which must be assignable to the registered type, or an error.
+// Parsers registered here take precedence over built-in ones and may be supplied over multiple calls.
+func WithParsers(parsers map[reflect.Type]env.ParserFunc) Option {
+	return func(opts *Options) error {
+		if opts.Parsers == nil {
+			opts.Parsers = make(map[reflect.Type]env.ParserFunc, len(parsers))
+		}
+
+		maps.Copy(opts.Parsers, parsers)
+		return nil
+	}
+}
+
+// WithResolutionTrace records every resolution step of Load into dst: files loaded or skipped,
+// file values overridden by already-set variables, keys read and defaults applied.
+// dst is reset at the start of each Load, so the loader must not be used concurrently.
+// The trace covers this loader only; the file loaders of other packages record none.
+func WithResolutionTrace(dst *[]TraceStep) Option {
+	return func(opts *Options) error {
+		if dst == nil {
+			return errors.New("trace destination is nil")
+		}
+
+		opts.Trace = dst
+		return nil
+	}
+}
+
+// WithLogger sets a logger that reports loaded and skipped files and parse timings.
+// By default the loader logs nothing.
+func WithLogger(logger Logger) Option {
+	return func(opts *Options) error {
+		opts.Logger = logger
+		return nil
+	}
+}
+
+// WithObserver sets a function that is called exactly once after every Load call,
+// successful or not, e.g. to record load metrics.
+func WithObserver(fn func(ObserveEvent)) Option {
+	return func(opts *Options) error {
+		opts.Observer = fn
+		return nil
+	}
+}
+
+// WithWarnings sets a function that is called once per soft issue detected during Load,
+// without failing it. Fields tagged deprecated:"use NEW_NAME instead" produce a warning
+// such as "OLD_NAME is deprecated: use NEW_NAME instead" when their variable is set.
+func WithWarnings(fn func(message string)) Option {
+	return func(opts *Options) error {
+		opts.Warnings = fn
+		return nil
+	}
+}
+
+// WithNormalizer registers a function that adjusts the parsed config before constraint tags
+// are checked, e.g. to lowercase an environment name or clamp a pool size. Normalizers run in
+// registration order, after the rules of normalize tags. T must match the loader's
+// configuration type, otherwise Load fails.
+func WithNormalizer[T any](fn func(cfg *T)) Option {
+	return func(opts *Options) error {
+		if fn == nil {
+			return errors.New("normalizer is nil")
+		}
+
+		opts.Normalizers = append(opts.Normalizers, func(cfg any) error {
+			typed, ok := cfg.(*T)
+			if !ok {
+				return fmt.Errorf("normalizer expects %T, got %T", (*T)(nil), cfg)
+			}
+
+			fn(typed)
+			return nil
+		})
+		return nil
+	}
+}
+
+// WithOnLoad registers a callback that runs after every successful Load, e.g. to derive a DSN
+// from host, port and user fields. It may modify cfg; an error aborts the load and is returned
+// from Load. Callbacks run in registration order. T must match the loader's configuration type,
+// otherwise Load fails.
+func WithOnLoad[T any](fn func(cfg *T) error) Option {
+	return func(opts *Options) error {
+		if fn == nil {
+			return errors.New("OnLoad callback is nil")
+		}
+
+		opts.OnLoad = append(opts.OnLoad, func(cfg any) error {
+			typed, ok := cfg.(*T)
+			if !ok {
+				return fmt.Errorf("OnLoad callback expects %T, got %T", (*T)(nil), cfg)
+			}
+
+			return fn(typed)
+		})
+		return nil
+	}
+}
+
+// WithSecretAccessLog writes an audit record to logger for every secret:"true" field
+// populated during Load, with the key and the source it was read from. Values are never logged.
+func WithSecretAccessLog(logger *slog.Logger) Option {
+	return func(opts *Options) error {
+		opts.SecretAccessLog = logger
+		return nil
+	}
+}
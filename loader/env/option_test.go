@@ -0,0 +1,310 @@
+package env_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	envparser "github.com/caarlos0/env/v11"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type CustomTagConfig struct {
+	AppName string `config:"APP_NAME"`
+	Port    int    `config:"PORT" envDefault:"8080"`
+
+	Database struct {
+		Host string `config:"HOST,required"`
+	} `envPrefix:"DB_"`
+}
+
+func TestLoaderWithTagName(t *testing.T) {
+	defer clearEnvironmentVariables("SVC_APP_NAME", "SVC_DB_HOST")
+
+	file := createTempEnvFile(t, "SVC_APP_NAME=tagged\nSVC_DB_HOST=db.internal\n")
+
+	loader, err := env.NewLoader[CustomTagConfig]([]string{file},
+		env.WithTagName("config"),
+		env.WithEnvPrefix("SVC_"),
+		env.WithStrict(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.AppName != "tagged" {
+		t.Errorf("AppName: expected %q, got %q", "tagged", cfg.AppName)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("Port: expected default %d, got %d", 8080, cfg.Port)
+	}
+	if cfg.Database.Host != "db.internal" {
+		t.Errorf("Database.Host: expected %q, got %q", "db.internal", cfg.Database.Host)
+	}
+}
+
+type VersionedConfig struct {
+	AppName string `env_v1:"APP_NAME" env_v2:"APP_NAME"`
+	DSN     string `env_v1:"DATABASE_URL" env_v2:"DB_DSN"`
+}
+
+func TestLoaderWithSchemaVersion(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "DATABASE_URL", "DB_DSN")
+
+	file := createTempEnvFile(t, "APP_NAME=versioned\nDATABASE_URL=postgres://old\nDB_DSN=postgres://new\n")
+
+	tests := []struct {
+		version int
+		dsn     string
+	}{
+		{version: 1, dsn: "postgres://old"},
+		{version: 2, dsn: "postgres://new"},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("v%d", tc.version), func(t *testing.T) {
+			loader, err := env.NewLoader[VersionedConfig]([]string{file}, env.WithSchemaVersion(tc.version))
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			if cfg.AppName != "versioned" {
+				t.Errorf("AppName: expected %q, got %q", "versioned", cfg.AppName)
+			}
+			if cfg.DSN != tc.dsn {
+				t.Errorf("DSN: expected %q, got %q", tc.dsn, cfg.DSN)
+			}
+		})
+	}
+
+	if _, err := env.NewLoader[VersionedConfig]([]string{file}, env.WithSchemaVersion(0)); err == nil {
+		t.Error("expected error for schema version 0, got nil")
+	}
+}
+
+type LogLevel int
+
+const (
+	LogLevelInfo LogLevel = iota
+	LogLevelDebug
+)
+
+type LogConfig struct {
+	Level LogLevel `env:"LOG_LEVEL"`
+}
+
+func TestLoaderWithParsers(t *testing.T) {
+	defer clearEnvironmentVariables("LOG_LEVEL")
+
+	file := createTempEnvFile(t, "LOG_LEVEL=debug")
+
+	loader, err := env.NewLoader[LogConfig]([]string{file}, env.WithParsers(map[reflect.Type]envparser.ParserFunc{
+		reflect.TypeFor[LogLevel](): func(v string) (any, error) {
+			switch v {
+			case "info":
+				return LogLevelInfo, nil
+			case "debug":
+				return LogLevelDebug, nil
+			default:
+				return nil, fmt.Errorf("unknown log level %q", v)
+			}
+		},
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Level != LogLevelDebug {
+		t.Errorf("Level: expected %v, got %v", LogLevelDebug, cfg.Level)
+	}
+}
+
+type BackendConfig struct {
+	Backends []struct {
+		Name string `env:"NAME"`
+		Port int    `env:"PORT"`
+	} `envPrefix:"BACKENDS" sortBy:"Name"`
+}
+
+func TestLoaderWithSortedSlices(t *testing.T) {
+	defer clearEnvironmentVariables("BACKENDS_0_NAME", "BACKENDS_0_PORT", "BACKENDS_1_NAME", "BACKENDS_1_PORT")
+
+	file := createTempEnvFile(t, "BACKENDS_0_NAME=west\nBACKENDS_0_PORT=8081\nBACKENDS_1_NAME=east\nBACKENDS_1_PORT=8080")
+
+	loader, err := env.NewLoader[BackendConfig]([]string{file}, env.WithSortedSlices())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if len(cfg.Backends) != 2 {
+		t.Fatalf("expected 2 backends, got %d", len(cfg.Backends))
+	}
+
+	if cfg.Backends[0].Name != "east" || cfg.Backends[0].Port != 8080 {
+		t.Errorf("expected east:8080 first, got %s:%d", cfg.Backends[0].Name, cfg.Backends[0].Port)
+	}
+
+	if cfg.Backends[1].Name != "west" || cfg.Backends[1].Port != 8081 {
+		t.Errorf("expected west:8081 second, got %s:%d", cfg.Backends[1].Name, cfg.Backends[1].Port)
+	}
+}
+
+func TestLoaderWithEnvironment(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ map[string]string
+		want    SampleConfig
+	}{
+		{
+			name:    "First map",
+			environ: map[string]string{"APP_NAME": "first", "PORT": "8080"},
+			want:    SampleConfig{AppName: "first", Port: 8080},
+		},
+		{
+			name:    "Second map",
+			environ: map[string]string{"APP_NAME": "second", "PORT": "9090"},
+			want:    SampleConfig{AppName: "second", Port: 9090},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			loader, err := env.NewLoader[SampleConfig](nil, env.WithEnvironment(tc.environ))
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			for range 100 {
+				cfg, err := loader.Load()
+				if err != nil {
+					t.Fatalf("unexpected error loading config: %v", err)
+				}
+
+				if *cfg != tc.want {
+					t.Fatalf("expected %+v, got %+v", tc.want, *cfg)
+				}
+			}
+
+			if _, exists := os.LookupEnv("APP_NAME"); exists {
+				t.Error("expected the process environment to be left untouched")
+			}
+		})
+	}
+}
+
+type PrefixedConfig struct {
+	Port int `env:"PORT"`
+
+	Database struct {
+		Host string `env:"HOST"`
+
+		Replica struct {
+			Host string `env:"HOST"`
+		} `envPrefix:"REPLICA_"`
+	} `envPrefix:"DB_"`
+}
+
+func TestLoaderWithEnvPrefixNested(t *testing.T) {
+	loader, err := env.NewLoader[PrefixedConfig](nil,
+		env.WithEnvironment(map[string]string{
+			"APP_PORT":            "8080",
+			"APP_DB_HOST":         "primary.internal",
+			"APP_DB_REPLICA_HOST": "replica.internal",
+			"DB_HOST":             "unprefixed.internal",
+		}),
+		env.WithEnvPrefix("APP_"),
+		env.WithStrict(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Port != 8080 {
+		t.Errorf("Port: expected 8080, got %d", cfg.Port)
+	}
+
+	if cfg.Database.Host != "primary.internal" {
+		t.Errorf("Database.Host: expected %q, got %q", "primary.internal", cfg.Database.Host)
+	}
+
+	if cfg.Database.Replica.Host != "replica.internal" {
+		t.Errorf("Database.Replica.Host: expected %q, got %q", "replica.internal", cfg.Database.Replica.Host)
+	}
+}
+
+func TestLoaderWithFileLoadOrder(t *testing.T) {
+	base := createTempEnvFile(t, "APP_NAME=base\nPORT=8080\n")
+	local := createTempEnvFile(t, "APP_NAME=local\n")
+
+	tests := []struct {
+		name string
+		opts []env.Option
+		want SampleConfig
+	}{
+		{
+			name: "Default",
+			want: SampleConfig{AppName: "base", Port: 8080},
+		},
+		{
+			name: "Highest first",
+			opts: []env.Option{env.WithFileLoadOrder(env.HighestFirst)},
+			want: SampleConfig{AppName: "base", Port: 8080},
+		},
+		{
+			name: "Lowest first",
+			opts: []env.Option{env.WithFileLoadOrder(env.LowestFirst)},
+			want: SampleConfig{AppName: "local", Port: 8080},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+			loader, err := env.NewLoader[SampleConfig]([]string{base, local}, tc.opts...)
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			assertConfigValues(t, cfg, &tc.want)
+		})
+	}
+
+	if _, err := env.NewLoader[SampleConfig]([]string{base}, env.WithFileLoadOrder(0)); err == nil {
+		t.Error("expected error for unknown file load order")
+	}
+}
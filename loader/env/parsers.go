@@ -0,0 +1,72 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+)
+
+// defaultParsers returns the parsers the loader registers in addition to those of the env parser.
+// Multi-value maps (url.Values, http.Header and map[string][]string) are read either in query
+// form with repeated keys ("a=1&a=2&b=3") or as a JSON object of string arrays.
+func defaultParsers() map[reflect.Type]env.ParserFunc {
+	return map[reflect.Type]env.ParserFunc{
+		reflect.TypeFor[map[string][]string](): func(v string) (any, error) {
+			return parseMultiValueMap(v)
+		},
+		reflect.TypeFor[url.Values](): func(v string) (any, error) {
+			m, err := parseMultiValueMap(v)
+			return url.Values(m), err
+		},
+		reflect.TypeFor[http.Header](): func(v string) (any, error) {
+			m, err := parseMultiValueMap(v)
+			if err != nil {
+				return nil, err
+			}
+
+			header := make(http.Header, len(m))
+			for key, values := range m {
+				for _, value := range values {
+					header.Add(key, value)
+				}
+			}
+
+			return header, nil
+		},
+	}
+}
+
+// parseMultiValueMap parses a query string or JSON object into a map of string slices
+func parseMultiValueMap(v string) (map[string][]string, error) {
+	v = strings.TrimSpace(v)
+
+	if strings.HasPrefix(v, "{") {
+		var m map[string][]string
+		if err := json.Unmarshal([]byte(v), &m); err != nil {
+			return nil, fmt.Errorf("invalid JSON multi-value map: %w", err)
+		}
+
+		return m, nil
+	}
+
+	m, err := url.ParseQuery(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query-style multi-value map: %w", err)
+	}
+
+	return m, nil
+}
+
+// withDefaultParsers returns funcMap extended with the default parsers; entries in funcMap take precedence
+func withDefaultParsers(funcMap map[reflect.Type]env.ParserFunc) map[reflect.Type]env.ParserFunc {
+	merged := defaultParsers()
+	maps.Copy(merged, funcMap)
+
+	return merged
+}
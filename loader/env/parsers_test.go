@@ -0,0 +1,53 @@
+package env_test
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type MultiValueConfig struct {
+	Query   url.Values          `env:"QUERY"`
+	Headers http.Header         `env:"HEADERS"`
+	Tags    map[string][]string `env:"TAGS"`
+}
+
+func TestLoaderParsesMultiValueMaps(t *testing.T) {
+	defer clearEnvironmentVariables("QUERY", "HEADERS", "TAGS")
+
+	file := createTempEnvFile(t, `
+		QUERY=scope=read&scope=write&limit=10
+		HEADERS='{"x-request-source":["gateway"],"accept":["application/json","text/plain"]}'
+		TAGS=team=core&team=platform
+	`)
+
+	loader, err := env.NewLoader[MultiValueConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	wantQuery := url.Values{"scope": {"read", "write"}, "limit": {"10"}}
+	if !reflect.DeepEqual(cfg.Query, wantQuery) {
+		t.Errorf("Query: expected %v, got %v", wantQuery, cfg.Query)
+	}
+
+	if got := cfg.Headers.Values("Accept"); !reflect.DeepEqual(got, []string{"application/json", "text/plain"}) {
+		t.Errorf("Headers[Accept]: expected two values, got %v", got)
+	}
+	if got := cfg.Headers.Get("X-Request-Source"); got != "gateway" {
+		t.Errorf("Headers[X-Request-Source]: expected %q, got %q", "gateway", got)
+	}
+
+	wantTags := map[string][]string{"team": {"core", "platform"}}
+	if !reflect.DeepEqual(cfg.Tags, wantTags) {
+		t.Errorf("Tags: expected %v, got %v", wantTags, cfg.Tags)
+	}
+}
@@ -0,0 +1,76 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLoaderExpandsFilePaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	tests := []struct {
+		name string
+		dir  string
+		path string
+	}{
+		{
+			name: "Home directory",
+			dir:  filepath.Join(home, ".config", "app"),
+			path: "~/.config/app/.env",
+		},
+		{
+			name: "Environment variable",
+			dir:  filepath.Join(configHome, "app"),
+			path: "$XDG_CONFIG_HOME/app/.env",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+			if err := os.MkdirAll(tc.dir, 0o755); err != nil {
+				t.Fatalf("failed to create config dir: %v", err)
+			}
+
+			if err := os.WriteFile(filepath.Join(tc.dir, ".env"), []byte("APP_NAME=expanded\nPORT=8080\n"), 0o600); err != nil {
+				t.Fatalf("failed to write env file: %v", err)
+			}
+
+			loader, err := env.NewLoader[SampleConfig]([]string{tc.path})
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			if cfg.AppName != "expanded" {
+				t.Errorf("expected AppName expanded, got %q", cfg.AppName)
+			}
+		})
+	}
+}
+
+func TestLoaderEmptyExpandedPath(t *testing.T) {
+	t.Setenv("UNSET_CONFIG_PATH", "")
+
+	loader, err := env.NewLoader[SampleConfig]([]string{"$UNSET_CONFIG_PATH"}, env.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, env.ErrEmptyPath) {
+		t.Errorf("expected ErrEmptyPath, got %v", err)
+	}
+}
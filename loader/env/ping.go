@@ -0,0 +1,72 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Ping checks that every env file passed to NewLoader exists and is readable, without parsing
+// it. Missing files that WithSkipMissingFiles allows to skip pass; files added through
+// WithEnvironmentFiles are optional and not checked. For a directory loader, the directory
+// must exist unless WithSkipMissingFiles is set. Loaders using WithEnvironment or
+// NewBytesLoader have nothing to check.
+func (l *Loader[T]) Ping(ctx context.Context) error {
+	if l.Options.Environment != nil {
+		return nil
+	}
+
+	for _, name := range l.Files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		file, err := expandPath(name)
+		if err != nil {
+			return fmt.Errorf("error checking env file %s: %w", name, err)
+		}
+
+		if err := checkEnvFile(file); err != nil {
+			if l.skipMissing(name) && errors.Is(err, ErrSourceNotFound) {
+				continue
+			}
+
+			return fmt.Errorf("error checking env file %s: %w", file, err)
+		}
+	}
+
+	if l.Dir != "" {
+		if _, err := l.dirFiles(); err != nil {
+			if l.Options.SkipMissingFiles && errors.Is(err, ErrSourceNotFound) {
+				return nil
+			}
+
+			return fmt.Errorf("error checking env directory %s: %w", l.Dir, err)
+		}
+	}
+
+	return nil
+}
+
+// checkEnvFile reports whether filename is a readable regular file
+func checkEnvFile(filename string) error {
+	info, err := os.Stat(filename)
+	if os.IsNotExist(err) {
+		return ErrSourceNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return ErrNotARegularFile
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
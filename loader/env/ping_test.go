@@ -0,0 +1,94 @@
+package env_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLoaderPing(t *testing.T) {
+	existing := createTempEnvFile(t, "PORT=8080\n")
+	dir := t.TempDir()
+	missingOptional := filepath.Join(dir, "optional.env")
+	missingRequired := filepath.Join(dir, "required.env")
+
+	tests := []struct {
+		name    string
+		files   []string
+		opts    []env.Option
+		wantErr error
+	}{
+		{
+			name:  "Existing file",
+			files: []string{existing},
+		},
+		{
+			name:  "Optional missing file",
+			files: []string{existing, missingOptional},
+			opts:  []env.Option{env.WithSkipMissingFiles()},
+		},
+		{
+			name:  "Required missing file",
+			files: []string{existing, missingOptional, missingRequired},
+			opts: []env.Option{
+				env.WithSkipMissingFiles(),
+				env.WithRequiredFiles(missingRequired),
+			},
+			wantErr: env.ErrSourceNotFound,
+		},
+		{
+			name:    "Missing file without skipping",
+			files:   []string{missingOptional},
+			wantErr: env.ErrSourceNotFound,
+		},
+		{
+			name:    "Directory",
+			files:   []string{dir},
+			opts:    []env.Option{env.WithSkipMissingFiles()},
+			wantErr: env.ErrNotARegularFile,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader, err := env.NewLoader[SampleConfig](tc.files, tc.opts...)
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			err = loader.Ping(context.Background())
+			if tc.wantErr == nil && err != nil {
+				t.Fatalf("unexpected ping error: %v", err)
+			}
+
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDirLoaderPing(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "conf.d")
+
+	loader, err := env.NewDirLoader[SampleConfig](missing)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); !errors.Is(err, env.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	loader, err = env.NewDirLoader[SampleConfig](missing, env.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); err != nil {
+		t.Errorf("expected missing directory to pass with WithSkipMissingFiles, got %v", err)
+	}
+}
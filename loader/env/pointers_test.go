@@ -0,0 +1,91 @@
+package env_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type PointerConfig struct {
+	Workers *int           `env:"WORKERS"`
+	Debug   *bool          `env:"DEBUG"`
+	Timeout *time.Duration `env:"TIMEOUT"`
+
+	Retries  *int           `env:"RETRIES" envDefault:"3"`
+	Verbose  *bool          `env:"VERBOSE" envDefault:"false"`
+	Interval *time.Duration `env:"INTERVAL" envDefault:"5s"`
+}
+
+func TestLoaderPointerFieldsUnset(t *testing.T) {
+	loader, err := env.NewLoader[PointerConfig](nil, env.WithEnvironment(map[string]string{
+		"DEBUG": "",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Workers != nil || cfg.Debug != nil || cfg.Timeout != nil {
+		t.Errorf("expected nil pointers without a value or default, got %v, %v, %v", cfg.Workers, cfg.Debug, cfg.Timeout)
+	}
+
+	if cfg.Retries == nil || *cfg.Retries != 3 {
+		t.Errorf("Retries: expected default 3, got %v", cfg.Retries)
+	}
+
+	if cfg.Verbose == nil || *cfg.Verbose {
+		t.Errorf("Verbose: expected default false, got %v", cfg.Verbose)
+	}
+
+	if cfg.Interval == nil || *cfg.Interval != 5*time.Second {
+		t.Errorf("Interval: expected default 5s, got %v", cfg.Interval)
+	}
+}
+
+func TestLoaderPointerFieldsSet(t *testing.T) {
+	loader, err := env.NewLoader[PointerConfig](nil, env.WithEnvironment(map[string]string{
+		"WORKERS":  "0",
+		"DEBUG":    "false",
+		"TIMEOUT":  "0s",
+		"RETRIES":  "0",
+		"VERBOSE":  "true",
+		"INTERVAL": "1m",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Workers == nil || *cfg.Workers != 0 {
+		t.Errorf("Workers: expected explicit 0, got %v", cfg.Workers)
+	}
+
+	if cfg.Debug == nil || *cfg.Debug {
+		t.Errorf("Debug: expected explicit false, got %v", cfg.Debug)
+	}
+
+	if cfg.Timeout == nil || *cfg.Timeout != 0 {
+		t.Errorf("Timeout: expected explicit 0s, got %v", cfg.Timeout)
+	}
+
+	if cfg.Retries == nil || *cfg.Retries != 0 {
+		t.Errorf("Retries: expected 0 overriding the default, got %v", cfg.Retries)
+	}
+
+	if cfg.Verbose == nil || !*cfg.Verbose {
+		t.Errorf("Verbose: expected true overriding the default, got %v", cfg.Verbose)
+	}
+
+	if cfg.Interval == nil || *cfg.Interval != time.Minute {
+		t.Errorf("Interval: expected 1m overriding the default, got %v", cfg.Interval)
+	}
+}
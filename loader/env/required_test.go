@@ -0,0 +1,106 @@
+package env_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestLoaderRequiredFiles(t *testing.T) {
+	dir := t.TempDir()
+	defaults := filepath.Join(dir, ".env.defaults")
+	secrets := filepath.Join(dir, ".env.secrets")
+
+	tests := []struct {
+		name        string
+		files       []string
+		required    []string
+		expectError bool
+	}{
+		{
+			name:     "Optional missing file is skipped",
+			files:    []string{defaults},
+			required: nil,
+		},
+		{
+			name:        "Required missing file fails",
+			files:       []string{defaults, secrets},
+			required:    []string{secrets},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader, err := env.NewLoader[SampleConfig](tc.files,
+				env.WithSkipMissingFiles(),
+				env.WithRequiredFiles(tc.required...),
+			)
+			if err != nil {
+				t.Fatalf("failed to create env loader: %v", err)
+			}
+
+			_, err = loader.Load()
+			if tc.expectError && !errors.Is(err, env.ErrSourceNotFound) {
+				t.Fatalf("expected ErrSourceNotFound, got %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewLoaderRequiredFileNotListed(t *testing.T) {
+	_, err := env.NewLoader[SampleConfig]([]string{".env"}, env.WithRequiredFiles(".env.secrets"))
+	if !errors.Is(err, env.ErrRequiredFileNotListed) {
+		t.Errorf("expected ErrRequiredFileNotListed, got %v", err)
+	}
+}
+
+func TestLoaderWithRequireAnyFile(t *testing.T) {
+	dir := t.TempDir()
+	missing := []string{filepath.Join(dir, ".env.typo"), filepath.Join(dir, ".env.locl")}
+
+	t.Run("All files missing", func(t *testing.T) {
+		loader, err := env.NewLoader[SampleConfig](missing, env.WithSkipMissingFiles())
+		if err != nil {
+			t.Fatalf("failed to create env loader: %v", err)
+		}
+
+		if _, err := loader.Load(); err != nil {
+			t.Fatalf("expected all-missing files to load defaults without the option, got %v", err)
+		}
+
+		loader, err = env.NewLoader[SampleConfig](missing, env.WithSkipMissingFiles(), env.WithRequireAnyFile())
+		if err != nil {
+			t.Fatalf("failed to create env loader: %v", err)
+		}
+
+		if _, err := loader.Load(); !errors.Is(err, env.ErrSourceNotFound) {
+			t.Fatalf("expected ErrSourceNotFound, got %v", err)
+		}
+	})
+
+	t.Run("One file present", func(t *testing.T) {
+		defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+		present := createTempEnvFile(t, "APP_NAME=present\n")
+
+		loader, err := env.NewLoader[SampleConfig](append(missing, present), env.WithSkipMissingFiles(), env.WithRequireAnyFile())
+		if err != nil {
+			t.Fatalf("failed to create env loader: %v", err)
+		}
+
+		cfg, err := loader.Load()
+		if err != nil {
+			t.Fatalf("unexpected error loading config: %v", err)
+		}
+
+		if cfg.AppName != "present" {
+			t.Errorf("AppName: expected %q, got %q", "present", cfg.AppName)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package env
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = map[string]func(ref string) (string, error){}
+
+	referencePattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*)://([^#]*#.+)$`)
+)
+
+// RegisterResolver registers fn as the resolver for references with the given scheme,
+// e.g. "vault" for values like vault://secret/data/db#password. The resolver receives the
+// reference without its scheme prefix ("secret/data/db#password").
+// Registering a scheme again replaces the previous resolver.
+func RegisterResolver(scheme string, fn func(ref string) (string, error)) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+
+	resolvers[scheme] = fn
+}
+
+// resolveReferences replaces reference values of the given fields in environ with their
+// resolved values. Values whose scheme has no registered resolver, such as an ordinary
+// https://host/#/path URL, are left untouched.
+func resolveReferences(environ map[string]string, keys []fields.Field) error {
+	for _, f := range keys {
+		value, ok := environ[f.Key]
+		if !ok {
+			continue
+		}
+
+		match := referencePattern.FindStringSubmatch(value)
+		if match == nil {
+			continue
+		}
+
+		resolve := resolver(match[1])
+		if resolve == nil {
+			continue
+		}
+
+		resolved, err := resolve(match[2])
+		if err != nil {
+			return fmt.Errorf("%s: failed to resolve %s reference: %w", f.Key, match[1], err)
+		}
+
+		environ[f.Key] = resolved
+	}
+
+	return nil
+}
+
+// resolver returns the resolver registered for scheme, or nil. The lock is released before
+// the resolver runs, so resolvers may register other schemes.
+func resolver(scheme string) func(ref string) (string, error) {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+
+	return resolvers[scheme]
+}
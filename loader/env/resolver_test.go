@@ -0,0 +1,108 @@
+package env_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type SecretRefConfig struct {
+	DBPassword string `env:"DB_PASSWORD"`
+	APIKey     string `env:"API_KEY"`
+	BaseURL    string `env:"BASE_URL"`
+}
+
+func TestLoaderResolvesReferences(t *testing.T) {
+	defer clearEnvironmentVariables("DB_PASSWORD", "API_KEY", "BASE_URL")
+
+	env.RegisterResolver("vault", func(ref string) (string, error) {
+		return "vault:" + ref, nil
+	})
+	env.RegisterResolver("aws-sm", func(ref string) (string, error) {
+		return "aws:" + ref, nil
+	})
+
+	file := createTempEnvFile(t, `
+		DB_PASSWORD=vault://secret/data/db#password
+		API_KEY=aws-sm://prod/api#key
+		BASE_URL=https://example.com/api
+	`)
+
+	loader, err := env.NewLoader[SecretRefConfig]([]string{file}, env.WithReferenceResolution())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.DBPassword != "vault:secret/data/db#password" {
+		t.Errorf("DBPassword: expected vault-resolved value, got %q", cfg.DBPassword)
+	}
+	if cfg.APIKey != "aws:prod/api#key" {
+		t.Errorf("APIKey: expected aws-resolved value, got %q", cfg.APIKey)
+	}
+	if cfg.BaseURL != "https://example.com/api" {
+		t.Errorf("BaseURL: expected value to be left untouched, got %q", cfg.BaseURL)
+	}
+}
+
+func TestLoaderUnregisteredSchemeIsNotAReference(t *testing.T) {
+	defer clearEnvironmentVariables("DB_PASSWORD", "BASE_URL")
+
+	file := createTempEnvFile(t, `
+		DB_PASSWORD=gcp-sm://projects/p/secrets/db#latest
+		BASE_URL=https://app.example.com/#/login
+	`)
+
+	loader, err := env.NewLoader[SecretRefConfig]([]string{file}, env.WithReferenceResolution())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.DBPassword != "gcp-sm://projects/p/secrets/db#latest" || cfg.BaseURL != "https://app.example.com/#/login" {
+		t.Errorf("expected values with unregistered schemes to be left untouched, got %+v", cfg)
+	}
+}
+
+func TestLoaderResolverRegistersScheme(t *testing.T) {
+	defer clearEnvironmentVariables("DB_PASSWORD")
+
+	env.RegisterResolver("lazy-vault", func(ref string) (string, error) {
+		env.RegisterResolver("lazy-vault-child", func(ref string) (string, error) {
+			return ref, nil
+		})
+
+		return "resolved", nil
+	})
+
+	file := createTempEnvFile(t, "DB_PASSWORD=lazy-vault://secret/db#password")
+
+	loader, err := env.NewLoader[SecretRefConfig]([]string{file}, env.WithReferenceResolution())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := loader.Load()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error loading config: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolver registering a scheme deadlocked")
+	}
+}
@@ -0,0 +1,231 @@
+package env
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// SaveEnvFile writes the env-tagged fields of cfg to path as KEY=value lines that the
+// env loader can read back. The file is written atomically via a temporary file and rename.
+// Nil pointer fields are omitted.
+func SaveEnvFile[T any](cfg *T, path string) error {
+	content, err := marshalEnv(cfg, nil)
+	if err != nil {
+		return fmt.Errorf("error saving env file %s: %w", path, err)
+	}
+
+	if err := writeFileAtomic(path, []byte(content)); err != nil {
+		return fmt.Errorf("error saving env file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// marshalEnv formats every env-tagged field of cfg as a KEY=value line.
+// If secret is not nil, it transforms the values of fields tagged secret:"true", given the
+// key and value of each.
+func marshalEnv[T any](cfg *T, secret func(key, value string) (string, error)) (string, error) {
+	if cfg == nil {
+		return "", ErrNilConfig
+	}
+
+	root := reflect.ValueOf(cfg)
+	if root.Elem().Kind() != reflect.Struct {
+		return "", ErrNotAStruct
+	}
+
+	var (
+		sb       strings.Builder
+		firstErr error
+	)
+
+	(fields.Walker{}).Walk(root.Type(), func(f fields.Field) {
+		if firstErr != nil {
+			return
+		}
+
+		v, ok := fields.Value(root, f.Index)
+		if !ok {
+			return
+		}
+
+		value, ok, err := formatValue(v, f.Struct)
+		if encoding, tagged := f.Struct.Tag.Lookup("encoding"); tagged && ok && err == nil {
+			value, err = encodeValue(v, encoding)
+		}
+
+		if secret != nil && f.Secret() && ok && err == nil {
+			value, err = secret(f.Key, value)
+		}
+
+		if err != nil {
+			firstErr = fmt.Errorf("field %s: %w", f.Name, err)
+			return
+		}
+
+		if !ok {
+			return
+		}
+
+		sb.WriteString(f.Key + "=" + quoteValue(value) + "\n")
+	})
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	return sb.String(), nil
+}
+
+// formatValue renders v in a form the env parser can read back.
+// It reports false for nil pointers, which should be omitted from the output.
+func formatValue(v reflect.Value, sf reflect.StructField) (string, bool, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", false, nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Duration:
+		return value.String(), true, nil
+	case url.Values:
+		return value.Encode(), true, nil
+	case http.Header:
+		return url.Values(value).Encode(), true, nil
+	case map[string][]string:
+		return url.Values(value).Encode(), true, nil
+	}
+
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), true, err
+	}
+
+	if v.CanAddr() {
+		if m, ok := v.Addr().Interface().(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			return string(text), true, err
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return formatSlice(v, sf)
+	case reflect.Map:
+		return formatMap(v, sf)
+	default:
+		s, err := formatScalar(v)
+		return s, true, err
+	}
+}
+
+func formatSlice(v reflect.Value, sf reflect.StructField) (string, bool, error) {
+	separator := sf.Tag.Get("envSeparator")
+	if separator == "" {
+		separator = ","
+	}
+
+	items := make([]string, 0, v.Len())
+	for i := range v.Len() {
+		item, _, err := formatValue(v.Index(i), sf)
+		if err != nil {
+			return "", false, err
+		}
+		items = append(items, item)
+	}
+
+	return strings.Join(items, separator), true, nil
+}
+
+func formatMap(v reflect.Value, sf reflect.StructField) (string, bool, error) {
+	separator := sf.Tag.Get("envSeparator")
+	if separator == "" {
+		separator = ","
+	}
+
+	keyValSeparator := sf.Tag.Get("envKeyValSeparator")
+	if keyValSeparator == "" {
+		keyValSeparator = ":"
+	}
+
+	items := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		key, _, err := formatValue(k, sf)
+		if err != nil {
+			return "", false, err
+		}
+
+		value, _, err := formatValue(v.MapIndex(k), sf)
+		if err != nil {
+			return "", false, err
+		}
+
+		items = append(items, key+keyValSeparator+value)
+	}
+	sort.Strings(items)
+
+	return strings.Join(items, separator), true, nil
+}
+
+func formatScalar(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package env_test
+
+import (
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type SnapshotConfig struct {
+	AppName string        `env:"APP_NAME"`
+	Motto   string        `env:"MOTTO"`
+	Port    int           `env:"PORT"`
+	Debug   bool          `env:"DEBUG"`
+	Timeout time.Duration `env:"TIMEOUT"`
+	Hosts   []string      `env:"HOSTS"`
+	Ratio   float64       `env:"RATIO"`
+	Retries *int          `env:"RETRIES"`
+	Query   url.Values    `env:"QUERY"`
+
+	Database struct {
+		Host string `env:"HOST"`
+	} `envPrefix:"DB_"`
+}
+
+func TestSaveEnvFileRoundTrip(t *testing.T) {
+	keys := []string{"APP_NAME", "MOTTO", "PORT", "DEBUG", "TIMEOUT", "HOSTS", "RATIO", "RETRIES", "QUERY", "DB_HOST"}
+	defer clearEnvironmentVariables(keys...)
+
+	retries := 3
+	want := &SnapshotConfig{
+		AppName: "snapshot",
+		Motto:   `say "hi" # to $USER`,
+		Port:    8080,
+		Debug:   true,
+		Timeout: 90 * time.Second,
+		Hosts:   []string{"a.example.com", "b.example.com"},
+		Ratio:   0.75,
+		Retries: &retries,
+		Query:   url.Values{"scope": {"read", "write"}},
+		Database: struct {
+			Host string `env:"HOST"`
+		}{Host: "db.internal"},
+	}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := env.SaveEnvFile(want, path); err != nil {
+		t.Fatalf("unexpected error saving env file: %v", err)
+	}
+
+	loader, err := env.NewLoader[SnapshotConfig]([]string{path})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	got, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch\nexpected: %+v\ngot:      %+v", want, got)
+	}
+}
+
+func TestSaveEnvFileNil(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := env.SaveEnvFile[SnapshotConfig](nil, path); err == nil {
+		t.Fatal("expected error saving nil config, got nil")
+	}
+}
@@ -0,0 +1,19 @@
+package env
+
+import (
+	"strings"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+// fileScheme is the URI scheme RegisterScheme registers, e.g. file://./app.env
+const fileScheme = "file"
+
+// RegisterScheme registers the env loader for file:// URIs with goconfig.RegisterLoader,
+// so that goconfig.NewLoaderFromURI[T]("file://./app.env") loads ./app.env with opts.
+// An absolute path takes three slashes, as in file:///etc/app.env.
+func RegisterScheme[T any](opts ...Option) {
+	goconfig.RegisterLoader(fileScheme, func(uri string) (goconfig.ConfigLoader[T], error) {
+		return NewLoader[T]([]string{strings.TrimPrefix(uri, fileScheme+"://")}, opts...)
+	})
+}
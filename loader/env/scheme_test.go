@@ -0,0 +1,28 @@
+package env_test
+
+import (
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+func TestRegisterScheme(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME", "PORT")
+
+	file := createTempEnvFile(t, "APP_NAME=from-uri\nPORT=8080\n")
+
+	env.RegisterScheme[SampleConfig]()
+
+	loader, err := goconfig.NewLoaderFromURI[SampleConfig]("file://" + file)
+	if err != nil {
+		t.Fatalf("failed to create loader from URI: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	assertConfigValues(t, cfg, &SampleConfig{AppName: "from-uri", Port: 8080})
+}
@@ -0,0 +1,106 @@
+package env
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// keyValueSeparator separates the key and value of a map item, matching the env parser default
+const keyValueSeparator = ":"
+
+// withListParsers returns funcMap extended with parsers that split the slice and map fields
+// among fs on sep. Types that already have a parser, and []byte, are left to that parser.
+func withListParsers(funcMap map[reflect.Type]env.ParserFunc, fs []fields.Field, sep string) map[reflect.Type]env.ParserFunc {
+	parsers := make(map[reflect.Type]env.ParserFunc, len(funcMap))
+	maps.Copy(parsers, funcMap)
+
+	for _, f := range fs {
+		t := f.Struct.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if _, exists := parsers[t]; exists {
+			continue
+		}
+
+		switch {
+		case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+			parsers[t] = sliceParser(t, sep, funcMap)
+		case t.Kind() == reflect.Map:
+			parsers[t] = mapParser(t, sep, funcMap)
+		}
+	}
+
+	return parsers
+}
+
+// sliceParser returns a parser for slice type t splitting items on sep
+func sliceParser(t reflect.Type, sep string, funcMap map[reflect.Type]env.ParserFunc) env.ParserFunc {
+	return func(v string) (any, error) {
+		parts := strings.Split(v, sep)
+
+		result := reflect.MakeSlice(t, 0, len(parts))
+		for _, part := range parts {
+			item, err := parseItem(t.Elem(), part, funcMap)
+			if err != nil {
+				return nil, err
+			}
+
+			result = reflect.Append(result, item)
+		}
+
+		return result.Interface(), nil
+	}
+}
+
+// mapParser returns a parser for map type t splitting key:value items on sep
+func mapParser(t reflect.Type, sep string, funcMap map[reflect.Type]env.ParserFunc) env.ParserFunc {
+	return func(v string) (any, error) {
+		result := reflect.MakeMap(t)
+		for _, part := range strings.Split(v, sep) {
+			rawKey, rawValue, ok := strings.Cut(part, keyValueSeparator)
+			if !ok {
+				return nil, fmt.Errorf("%q should be in \"key%svalue\" format", part, keyValueSeparator)
+			}
+
+			key, err := parseItem(t.Key(), rawKey, funcMap)
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := parseItem(t.Elem(), rawValue, funcMap)
+			if err != nil {
+				return nil, err
+			}
+
+			result.SetMapIndex(key, value)
+		}
+
+		return result.Interface(), nil
+	}
+}
+
+// parseItem parses a single slice or map item of type t with the env parser, so items
+// support the same types, text unmarshalers and custom parsers as plain fields
+func parseItem(t reflect.Type, v string, funcMap map[reflect.Type]env.ParserFunc) (reflect.Value, error) {
+	holder := reflect.New(reflect.StructOf([]reflect.StructField{
+		{Name: "Value", Type: t, Tag: `env:"VALUE"`},
+	}))
+
+	err := env.ParseWithOptions(holder.Interface(), env.Options{
+		Environment: map[string]string{"VALUE": v},
+		FuncMap:     funcMap,
+	})
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid item %q: %w", v, err)
+	}
+
+	return holder.Elem().Field(0), nil
+}
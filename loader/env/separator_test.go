@@ -0,0 +1,80 @@
+package env_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type PathConfig struct {
+	Dirs      []string          `env:"DIRS"`
+	Timeouts  []time.Duration   `env:"TIMEOUTS"`
+	Labels    map[string]string `env:"LABELS"`
+	Separated []string          `env:"SEPARATED" envSeparator:";"`
+}
+
+func TestLoaderWithListSeparator(t *testing.T) {
+	loader, err := env.NewLoader[PathConfig](nil,
+		env.WithEnvironment(map[string]string{
+			"DIRS":      "/a:/b,c:/d",
+			"TIMEOUTS":  "1s:2m",
+			"SEPARATED": "x:y",
+		}),
+		env.WithListSeparator(":"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if want := []string{"/a", "/b,c", "/d"}; !reflect.DeepEqual(cfg.Dirs, want) {
+		t.Errorf("Dirs: expected %v, got %v", want, cfg.Dirs)
+	}
+
+	if want := []time.Duration{time.Second, 2 * time.Minute}; !reflect.DeepEqual(cfg.Timeouts, want) {
+		t.Errorf("Timeouts: expected %v, got %v", want, cfg.Timeouts)
+	}
+
+	if want := []string{"x", "y"}; !reflect.DeepEqual(cfg.Separated, want) {
+		t.Errorf("Separated: expected %v, got %v", want, cfg.Separated)
+	}
+}
+
+func TestLoaderWithListSeparatorMap(t *testing.T) {
+	loader, err := env.NewLoader[PathConfig](nil,
+		env.WithEnvironment(map[string]string{"LABELS": "team:core|env:prod"}),
+		env.WithListSeparator("|"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if want := map[string]string{"team": "core", "env": "prod"}; !reflect.DeepEqual(cfg.Labels, want) {
+		t.Errorf("Labels: expected %v, got %v", want, cfg.Labels)
+	}
+}
+
+func TestLoaderWithListSeparatorInvalidItem(t *testing.T) {
+	loader, err := env.NewLoader[PathConfig](nil,
+		env.WithEnvironment(map[string]string{"TIMEOUTS": "1s:forever"}),
+		env.WithListSeparator(":"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err == nil {
+		t.Fatal("expected an error for an invalid duration item")
+	}
+}
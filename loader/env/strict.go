@@ -0,0 +1,75 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// checkUnknownVariables reports every prefixed variable in environ that matches no field of T
+func (l *Loader[T]) checkUnknownVariables(environ map[string]string) error {
+	prefix := l.envOptions().Prefix
+
+	known := make(map[string]struct{})
+	var collected []string
+	for _, f := range l.walker().Fields(reflect.TypeFor[T]()) {
+		known[f.Key] = struct{}{}
+		if l.Options.MapCollection && isCollectedMap(f) {
+			collected = append(collected, f.Key)
+		}
+	}
+
+	// Slice-of-struct elements are read from <prefix><index>_<element key>, by the env parser
+	// itself and, with sparse indices, after WithIndexedSlices compacts them
+	indexed := make(map[string]map[string]struct{})
+	walker := l.walker()
+	for _, slice := range indexedSlices(reflect.TypeFor[T](), walker) {
+		elemKeys := make(map[string]struct{})
+
+		walker.Prefix = ""
+		for _, f := range walker.Fields(slice.Elem) {
+			elemKeys[f.Key] = struct{}{}
+		}
+
+		indexed[slice.Prefix] = elemKeys
+	}
+
+	var unknown []string
+	for key := range environ {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		isCollected := slices.ContainsFunc(collected, func(p string) bool {
+			return strings.HasPrefix(key, p)
+		})
+
+		if _, ok := known[key]; !ok && !isCollected && !isIndexedKey(key, indexed) {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+
+	return fmt.Errorf("%w with prefix %s: %s", ErrUnknownVariables, prefix, strings.Join(unknown, ", "))
+}
+
+// isIndexedKey reports whether key is an element key of one of the indexed slices, given as
+// a map from slice prefix to the keys of its element fields
+func isIndexedKey(key string, indexed map[string]map[string]struct{}) bool {
+	for prefix, elemKeys := range indexed {
+		if _, rest, ok := splitIndexedKey(key, prefix); ok {
+			if _, ok := elemKeys[rest]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,123 @@
+package env_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type BillingConfig struct {
+	Type string `env:"TYPE"`
+	Port int    `env:"PORT"`
+}
+
+func TestLoaderStrictRejectsUnknownVariables(t *testing.T) {
+	defer clearEnvironmentVariables("BILLING_TYPE", "BILLING_TYO", "BILLING_PROT")
+
+	file := createTempEnvFile(t, "BILLING_TYPE=card\nBILLING_TYO=invoice\nBILLING_PROT=8080\n")
+
+	loader, err := env.NewLoader[BillingConfig]([]string{file}, env.WithEnvPrefix("BILLING_"), env.WithStrict())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	_, err = loader.Load()
+	if !errors.Is(err, env.ErrUnknownVariables) {
+		t.Fatalf("expected ErrUnknownVariables, got %v", err)
+	}
+
+	for _, key := range []string{"BILLING_TYO", "BILLING_PROT"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("expected error to mention %s, got %v", key, err)
+		}
+	}
+}
+
+func TestLoaderStrictAcceptsKnownVariables(t *testing.T) {
+	defer clearEnvironmentVariables("BILLING_TYPE", "BILLING_PORT")
+
+	file := createTempEnvFile(t, "BILLING_TYPE=card\nBILLING_PORT=8080\n")
+
+	loader, err := env.NewLoader[BillingConfig]([]string{file}, env.WithEnvPrefix("BILLING_"), env.WithStrict())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Type != "card" || cfg.Port != 8080 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoaderStrictRequiresPrefix(t *testing.T) {
+	_, err := env.NewLoader[BillingConfig]([]string{".env"}, env.WithStrict())
+	if !errors.Is(err, env.ErrStrictWithoutPrefix) {
+		t.Fatalf("expected ErrStrictWithoutPrefix, got %v", err)
+	}
+}
+
+func TestLoaderStrictAcceptsIndexedSliceKeys(t *testing.T) {
+	environ := map[string]string{
+		"APP_SERVERS_0_HOST": "a.internal",
+		"APP_SERVERS_0_PORT": "8080",
+		"APP_SERVERS_3_HOST": "b.internal",
+	}
+
+	loader, err := env.NewLoader[ClusterConfig](nil,
+		env.WithEnvironment(environ),
+		env.WithEnvPrefix("APP_"),
+		env.WithIndexedSlices(),
+		env.WithStrict(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if len(cfg.Servers) != 2 || cfg.Servers[1].Host != "b.internal" {
+		t.Errorf("expected two servers, got %+v", cfg.Servers)
+	}
+
+	environ["APP_SERVERS_0_HOTS"] = "typo.internal"
+
+	_, err = loader.Load()
+	if !errors.Is(err, env.ErrUnknownVariables) || !strings.Contains(err.Error(), "APP_SERVERS_0_HOTS") {
+		t.Fatalf("expected ErrUnknownVariables for APP_SERVERS_0_HOTS, got %v", err)
+	}
+}
+
+func TestLoaderStrictAcceptsNativeSliceKeys(t *testing.T) {
+	environ := map[string]string{
+		"APP_SERVERS_0_HOST": "a.internal",
+		"APP_SERVERS_1_HOST": "b.internal",
+		"APP_SERVERS_1_PORT": "8081",
+	}
+
+	loader, err := env.NewLoader[ClusterConfig](nil,
+		env.WithEnvironment(environ),
+		env.WithEnvPrefix("APP_"),
+		env.WithStrict(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if len(cfg.Servers) != 2 || cfg.Servers[1].Port != 8081 {
+		t.Errorf("expected two servers, got %+v", cfg.Servers)
+	}
+}
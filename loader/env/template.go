@@ -0,0 +1,52 @@
+package env
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// GenerateEnvTemplate renders a commented .env template for the configuration type T.
+// Every field with an env tag becomes a KEY=default line, preceded by a comment taken
+// from its doc tag. Required fields are marked in the comment.
+func GenerateEnvTemplate[T any]() (string, error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return "", ErrNotAStruct
+	}
+
+	var sb strings.Builder
+	for i, f := range (fields.Walker{}).Fields(t) {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		comment := f.Struct.Tag.Get("doc")
+		if f.Required() {
+			comment = strings.TrimSpace(comment + " (required)")
+		}
+
+		if comment != "" {
+			sb.WriteString("# " + comment + "\n")
+		}
+
+		sb.WriteString(f.Key + "=" + quoteValue(f.Default) + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+// quoteValue double-quotes a value when it cannot be written verbatim into an env file
+func quoteValue(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n\"'#$\\=`") {
+		return value
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`)
+	return `"` + replacer.Replace(value) + `"`
+}
@@ -0,0 +1,50 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type TemplateConfig struct {
+	AppName string `env:"APP_NAME" envDefault:"myapp" doc:"Application name"`
+	Port    int    `env:"PORT,required" doc:"HTTP listen port"`
+	Greet   string `env:"GREETING" envDefault:"hello world"`
+	Token   string `env:"TOKEN,required"`
+
+	Database struct {
+		Host string `env:"HOST" envDefault:"localhost" doc:"Database host"`
+	} `envPrefix:"DB_"`
+}
+
+const templateGolden = `# Application name
+APP_NAME=myapp
+
+# HTTP listen port (required)
+PORT=
+
+GREETING="hello world"
+
+# (required)
+TOKEN=
+
+# Database host
+DB_HOST=localhost
+`
+
+func TestGenerateEnvTemplate(t *testing.T) {
+	got, err := env.GenerateEnvTemplate[TemplateConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != templateGolden {
+		t.Errorf("template mismatch\nexpected:\n%s\ngot:\n%s", templateGolden, got)
+	}
+}
+
+func TestGenerateEnvTemplateNotAStruct(t *testing.T) {
+	if _, err := env.GenerateEnvTemplate[string](); err == nil {
+		t.Fatal("expected error for non-struct type, got nil")
+	}
+}
@@ -0,0 +1,62 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ErrInvalidTime indicates that a time.Time value does not match its layout.
+var ErrInvalidTime = errors.New("invalid time value")
+
+// timeType is the type of time.Time fields
+var timeType = reflect.TypeFor[time.Time]()
+
+// convertTimeValues parses the values of time.Time fields in environ with their timeLayout tag
+// or, without one, the layout set by WithTimeLayout, falling back to their envDefault when the
+// key is unset or empty. Parsed values are rewritten as RFC 3339, the form the env parser expects.
+func convertTimeValues(environ map[string]string, keys []fields.Field, layout string) error {
+	for _, f := range keys {
+		fieldLayout, ok := f.Struct.Tag.Lookup("timeLayout")
+		if !ok {
+			fieldLayout = layout
+		}
+
+		if fieldLayout == "" {
+			continue
+		}
+
+		t := f.Struct.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t != timeType {
+			if ok {
+				return fmt.Errorf("field %s: timeLayout tag requires a time.Time field, got %s", f.Name, f.Struct.Type)
+			}
+			continue
+		}
+
+		raw, ok := environ[f.Key]
+		if (!ok || raw == "") && f.HasDefault {
+			raw, ok = f.Default, true
+		}
+
+		if !ok || raw == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(fieldLayout, raw)
+		if err != nil {
+			return fmt.Errorf("%w: field %s (%s): %q does not match layout %q", ErrInvalidTime, f.Name, f.Key, raw, fieldLayout)
+		}
+
+		environ[f.Key] = parsed.Format(time.RFC3339Nano)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package env_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type MaintenanceConfig struct {
+	MaintenanceStart time.Time  `env:"MAINTENANCE_START" timeLayout:"2006-01-02"`
+	DailyBackup      *time.Time `env:"DAILY_BACKUP" timeLayout:"15:04"`
+	Deadline         time.Time  `env:"DEADLINE" envDefault:"31.12.2030"`
+	CreatedAt        time.Time  `env:"CREATED_AT"`
+}
+
+func TestLoaderTimeLayout(t *testing.T) {
+	loader, err := env.NewLoader[MaintenanceConfig](nil,
+		env.WithTimeLayout("02.01.2006"),
+		env.WithEnvironment(map[string]string{
+			"MAINTENANCE_START": "2025-03-14",
+			"DAILY_BACKUP":      "02:30",
+			"CREATED_AT":        "01.06.2024",
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if want := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC); !cfg.MaintenanceStart.Equal(want) {
+		t.Errorf("MaintenanceStart: expected %v, got %v", want, cfg.MaintenanceStart)
+	}
+	if cfg.DailyBackup == nil || cfg.DailyBackup.Hour() != 2 || cfg.DailyBackup.Minute() != 30 {
+		t.Errorf("DailyBackup: expected 02:30, got %v", cfg.DailyBackup)
+	}
+	if want := time.Date(2030, 12, 31, 0, 0, 0, 0, time.UTC); !cfg.Deadline.Equal(want) {
+		t.Errorf("Deadline: expected default %v, got %v", want, cfg.Deadline)
+	}
+	if want := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC); !cfg.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt: expected %v, got %v", want, cfg.CreatedAt)
+	}
+}
+
+func TestLoaderTimeLayoutInvalidValue(t *testing.T) {
+	type Config struct {
+		MaintenanceStart time.Time `env:"MAINTENANCE_START" timeLayout:"2006-01-02"`
+	}
+
+	loader, err := env.NewLoader[Config](nil, env.WithEnvironment(map[string]string{
+		"MAINTENANCE_START": "14/03/2025",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	_, err = loader.Load()
+	if !errors.Is(err, env.ErrInvalidTime) {
+		t.Fatalf("expected ErrInvalidTime, got %v", err)
+	}
+
+	for _, want := range []string{"MaintenanceStart", "MAINTENANCE_START", `"2006-01-02"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %s, got %v", want, err)
+		}
+	}
+}
+
+func TestLoaderTimeLayoutWrongFieldType(t *testing.T) {
+	type Config struct {
+		Start string `env:"START" timeLayout:"2006-01-02"`
+	}
+
+	loader, err := env.NewLoader[Config](nil, env.WithEnvironment(map[string]string{"START": "2025-03-14"}))
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err == nil || !strings.Contains(err.Error(), "requires a time.Time field") {
+		t.Errorf("expected field type error, got %v", err)
+	}
+}
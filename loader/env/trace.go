@@ -0,0 +1,121 @@
+package env
+
+// TraceKind identifies the kind of a resolution step
+type TraceKind string
+
+const (
+	// TraceFileLoaded records that an env file was read
+	TraceFileLoaded TraceKind = "file_loaded"
+
+	// TraceFileSkipped records that a missing env file was skipped
+	TraceFileSkipped TraceKind = "file_skipped"
+
+	// TraceOverridden records that a file value was ignored because the key was already set,
+	// either in the process environment or by a file with higher precedence
+	TraceOverridden TraceKind = "overridden"
+
+	// TraceKeyRead records that a field was populated from an environment variable
+	TraceKeyRead TraceKind = "key_read"
+
+	// TraceDefaultApplied records that a field was populated from its envDefault tag
+	TraceDefaultApplied TraceKind = "default_applied"
+)
+
+// TraceStep describes a single step taken while resolving the configuration.
+// Values are never recorded, so traces are safe to log even when they cover secrets.
+type TraceStep struct {
+	Kind TraceKind
+
+	// Source is the file involved in the step, "data" for values passed to NewBytesLoader,
+	// or "environment" for process variables
+	Source string
+
+	// Key is the environment key involved in the step, if any
+	Key string
+}
+
+const (
+	// sourceEnvironment is the trace source for values that did not come from a loaded file
+	sourceEnvironment = "environment"
+
+	// sourceData is the trace source for values passed to NewBytesLoader
+	sourceData = "data"
+)
+
+// tracer records resolution steps for a single Load call; a nil tracer records nothing
+type tracer struct {
+	steps   *[]TraceStep
+	sources map[string]string
+}
+
+// newTracer returns a tracer recording into dst. If dst is nil, the tracer only tracks
+// which source set each key, or is nil when trackSources is false as well.
+func newTracer(dst *[]TraceStep, trackSources bool) *tracer {
+	if dst == nil && !trackSources {
+		return nil
+	}
+
+	if dst != nil {
+		*dst = (*dst)[:0]
+	}
+
+	return &tracer{
+		steps:   dst,
+		sources: make(map[string]string),
+	}
+}
+
+func (t *tracer) record(kind TraceKind, source, key string) {
+	if t == nil || t.steps == nil {
+		return
+	}
+
+	*t.steps = append(*t.steps, TraceStep{Kind: kind, Source: source, Key: key})
+}
+
+// fileLoaded records that file was read
+func (t *tracer) fileLoaded(file string) {
+	t.record(TraceFileLoaded, file, "")
+}
+
+// fileSkipped records that a missing file was skipped
+func (t *tracer) fileSkipped(file string) {
+	t.record(TraceFileSkipped, file, "")
+}
+
+// keySet remembers that key was set from file
+func (t *tracer) keySet(file, key string) {
+	if t == nil {
+		return
+	}
+
+	t.sources[key] = file
+}
+
+// keyOverridden records that the value of key in file was ignored
+func (t *tracer) keyOverridden(file, key string) {
+	t.record(TraceOverridden, file, key)
+}
+
+// onSet records the value the parser assigned to key
+func (t *tracer) onSet(key string, isDefault bool) {
+	if t == nil {
+		return
+	}
+
+	if isDefault {
+		t.record(TraceDefaultApplied, "", key)
+		return
+	}
+
+	t.record(TraceKeyRead, t.source(key), key)
+}
+
+// source returns the file that set key, or "environment" if no loaded file did
+func (t *tracer) source(key string) string {
+	if source, ok := t.sources[key]; ok {
+		return source
+	}
+
+	return sourceEnvironment
+}
@@ -0,0 +1,86 @@
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type TracedConfig struct {
+	AppName string `env:"APP_NAME"`
+	Port    int    `env:"PORT"`
+	Level   string `env:"LEVEL" envDefault:"info"`
+}
+
+func TestLoaderResolutionTrace(t *testing.T) {
+	defer clearEnvironmentVariables("APP_NAME")
+
+	t.Setenv("PORT", "7000")
+
+	dir := t.TempDir()
+	local := filepath.Join(dir, ".env.local")
+	base := filepath.Join(dir, ".env")
+	missing := filepath.Join(dir, ".env.missing")
+
+	if err := os.WriteFile(local, []byte("APP_NAME=local\n"), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", local, err)
+	}
+	if err := os.WriteFile(base, []byte("APP_NAME=base\nPORT=8080\n"), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", base, err)
+	}
+
+	var trace []env.TraceStep
+	loader, err := env.NewLoader[TracedConfig]([]string{local, missing, base},
+		env.WithSkipMissingFiles(),
+		env.WithResolutionTrace(&trace),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	want := []env.TraceStep{
+		{Kind: env.TraceFileLoaded, Source: local},
+		{Kind: env.TraceFileSkipped, Source: missing},
+		{Kind: env.TraceFileLoaded, Source: base},
+		{Kind: env.TraceOverridden, Source: base, Key: "APP_NAME"},
+		{Kind: env.TraceOverridden, Source: base, Key: "PORT"},
+		{Kind: env.TraceKeyRead, Source: local, Key: "APP_NAME"},
+		{Kind: env.TraceKeyRead, Source: "environment", Key: "PORT"},
+		{Kind: env.TraceDefaultApplied, Key: "LEVEL"},
+	}
+
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace mismatch\nexpected: %+v\ngot:      %+v", want, trace)
+	}
+}
+
+func TestLoaderResolutionTraceUnsetKey(t *testing.T) {
+	var trace []env.TraceStep
+	loader, err := env.NewLoader[TracedConfig](nil,
+		env.WithEnvironment(map[string]string{"APP_NAME": "traced"}),
+		env.WithResolutionTrace(&trace),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	want := []env.TraceStep{
+		{Kind: env.TraceKeyRead, Source: "environment", Key: "APP_NAME"},
+		{Kind: env.TraceDefaultApplied, Key: "LEVEL"},
+	}
+
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("expected no step for the unset PORT\nexpected: %+v\ngot:      %+v", want, trace)
+	}
+}
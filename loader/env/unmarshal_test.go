@@ -0,0 +1,71 @@
+package env_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+// CustomConfig decodes itself; its env tag points to a key that is never set,
+// so a value only appears if LoadFrom is used instead of reflection
+type CustomConfig struct {
+	Endpoint string `env:"UNUSED_ENDPOINT,required"`
+	Port     int
+	calls    int
+}
+
+func (c *CustomConfig) LoadFrom(raw map[string]any) error {
+	c.calls++
+
+	host, _ := raw["CUSTOM_HOST"].(string)
+	port, _ := raw["CUSTOM_PORT"].(string)
+	if host == "" {
+		return errors.New("CUSTOM_HOST is missing")
+	}
+
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return err
+	}
+
+	c.Endpoint = host + ":" + port
+	c.Port = n
+
+	return nil
+}
+
+func TestLoaderUsesLoadFrom(t *testing.T) {
+	defer clearEnvironmentVariables("CUSTOM_HOST", "CUSTOM_PORT")
+
+	file := createTempEnvFile(t, "CUSTOM_HOST=api.internal\nCUSTOM_PORT=9000\n")
+
+	loader, err := env.NewLoader[CustomConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.calls != 1 {
+		t.Errorf("expected LoadFrom to be called once, got %d", cfg.calls)
+	}
+	if cfg.Endpoint != "api.internal:9000" || cfg.Port != 9000 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoaderLoadFromError(t *testing.T) {
+	loader, err := env.NewLoader[CustomConfig]([]string{"missing.env"}, env.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err == nil {
+		t.Fatal("expected LoadFrom error, got nil")
+	}
+}
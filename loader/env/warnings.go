@@ -0,0 +1,32 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// warnDeprecated reports every field tagged deprecated:"..." whose variable is set in environ
+// through the handler registered with WithWarnings
+func (l *Loader[T]) warnDeprecated(environ map[string]string) {
+	if l.Options.Warnings == nil {
+		return
+	}
+
+	for _, f := range l.walker().Fields(reflect.TypeFor[T]()) {
+		message, deprecated := f.Struct.Tag.Lookup("deprecated")
+		if !deprecated {
+			continue
+		}
+
+		if _, set := environ[f.Key]; !set {
+			continue
+		}
+
+		if message == "" {
+			l.Options.Warnings(fmt.Sprintf("%s is deprecated", f.Key))
+			continue
+		}
+
+		l.Options.Warnings(fmt.Sprintf("%s is deprecated: %s", f.Key, message))
+	}
+}
@@ -0,0 +1,41 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig/loader/env"
+)
+
+type DeprecatedConfig struct {
+	DatabaseURL string `env:"DATABASE_URL"`
+	DBHost      string `env:"DB_HOST" deprecated:"use DATABASE_URL instead"`
+	DBPort      int    `env:"DB_PORT" deprecated:"use DATABASE_URL instead"`
+}
+
+func TestLoaderWithWarningsDeprecated(t *testing.T) {
+	var warnings []string
+
+	loader, err := env.NewLoader[DeprecatedConfig](nil,
+		env.WithEnvironment(map[string]string{"DB_HOST": "db.internal"}),
+		env.WithWarnings(func(message string) {
+			warnings = append(warnings, message)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create env loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.DBHost != "db.internal" {
+		t.Errorf("expected deprecated field to still be loaded, got %q", cfg.DBHost)
+	}
+
+	want := "DB_HOST is deprecated: use DATABASE_URL instead"
+	if len(warnings) != 1 || warnings[0] != want {
+		t.Errorf("expected warnings [%q], got %q", want, warnings)
+	}
+}
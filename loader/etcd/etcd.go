@@ -0,0 +1,181 @@
+// Package etcd provides a configuration loader that reads the keys under an etcd v3
+// prefix and maps them onto struct fields.
+//
+// Fields are bound with `etcd:"key"` tags, where key is relative to the loader prefix:
+// with prefix "/services/billing/", the etcd key /services/billing/db/host is bound by
+// `etcd:"db/host"`. Nested structs may declare an etcdPrefix tag (e.g. `etcdPrefix:"db/"`)
+// and etcdDefault provides defaults. Values are converted with the same rules as the env
+// loader, so every type supported there is supported here.
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+// ErrSourceNotFound indicates that no keys exist under the loader prefix.
+// It is goconfig.ErrSourceNotFound.
+var ErrSourceNotFound = goconfig.ErrSourceNotFound
+
+const (
+	tagName             = "etcd"
+	prefixTagName       = "etcdPrefix"
+	defaultValueTagName = "etcdDefault"
+)
+
+// KV is the subset of the etcd client used by the loader. *clientv3.Client implements it.
+type KV interface {
+	Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error)
+	Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan
+}
+
+// Loader implements configuration loading from etcd
+type Loader[T any] struct {
+	client  KV
+	prefix  string
+	options Options
+}
+
+// NewLoader creates a config loader that reads every key under prefix
+func NewLoader[T any](client KV, prefix string, opts ...Option) (*Loader[T], error) {
+	if client == nil {
+		return nil, errors.New("error creating loader: etcd client is nil")
+	}
+
+	loader := &Loader[T]{
+		client: client,
+		prefix: prefix,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	return loader, nil
+}
+
+// Load loads the configuration from etcd
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext loads the configuration from etcd using ctx for the request.
+// It returns ErrSourceNotFound if no keys exist under the prefix.
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	if l.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.options.Timeout)
+		defer cancel()
+	}
+
+	resp, err := l.client.Get(ctx, l.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("error reading etcd prefix %q: %w", l.prefix, err)
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, fmt.Errorf("error reading etcd prefix %q: %w", l.prefix, ErrSourceNotFound)
+	}
+
+	values := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		values[strings.TrimPrefix(string(kv.Key), l.prefix)] = string(kv.Value)
+	}
+
+	var cfg T
+	err = env.ParseWithOptions(&cfg, env.Options{
+		Environment:         values,
+		TagName:             tagName,
+		PrefixTagName:       prefixTagName,
+		DefaultValueTagName: defaultValueTagName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing etcd values into struct: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Ping checks that etcd is reachable and has keys under the prefix, without fetching their values.
+// It returns ErrSourceNotFound if no keys exist under the prefix.
+func (l *Loader[T]) Ping(ctx context.Context) error {
+	if l.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.options.Timeout)
+		defer cancel()
+	}
+
+	resp, err := l.client.Get(ctx, l.prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return fmt.Errorf("error checking etcd prefix %q: %w", l.prefix, err)
+	}
+
+	if resp.Count == 0 {
+		return fmt.Errorf("error checking etcd prefix %q: %w", l.prefix, ErrSourceNotFound)
+	}
+
+	return nil
+}
+
+// Watch watches the keys under the prefix and sends a freshly loaded configuration
+// whenever any of them changes. Reload and watch failures are passed to the handler set
+// with WithWatchErrorHandler and do not stop watching. The channel is closed when ctx is
+// done or the etcd watch ends.
+func (l *Loader[T]) Watch(ctx context.Context) <-chan *T {
+	updates := make(chan *T)
+	events := l.client.Watch(ctx, l.prefix, clientv3.WithPrefix())
+
+	go func() {
+		defer close(updates)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case resp, ok := <-events:
+				if !ok {
+					return
+				}
+
+				if err := resp.Err(); err != nil {
+					l.watchError(fmt.Errorf("error watching etcd prefix %q: %w", l.prefix, err))
+					continue
+				}
+
+				if len(resp.Events) == 0 {
+					continue
+				}
+
+				cfg, err := l.LoadContext(ctx)
+				if err != nil {
+					l.watchError(err)
+					continue
+				}
+
+				select {
+				case updates <- cfg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return updates
+}
+
+// watchError passes err to the configured watch error handler, if any
+func (l *Loader[T]) watchError(err error) {
+	if l.options.WatchErrorHandler != nil {
+		l.options.WatchErrorHandler(err)
+	}
+}
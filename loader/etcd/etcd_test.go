@@ -0,0 +1,179 @@
+package etcd_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/nikita-shtimenko/goconfig/loader/etcd"
+)
+
+type ServiceConfig struct {
+	Name string `etcd:"name"`
+
+	Database struct {
+		Host string `etcd:"host"`
+		Port int    `etcd:"port" etcdDefault:"5432"`
+	} `etcdPrefix:"db/"`
+}
+
+// fakeKV is an in-memory KV whose watch events are pushed by the test
+type fakeKV struct {
+	mu     sync.Mutex
+	values map[string]string
+	err    error
+	events chan clientv3.WatchResponse
+}
+
+func newFakeKV(values map[string]string) *fakeKV {
+	return &fakeKV{
+		values: values,
+		events: make(chan clientv3.WatchResponse),
+	}
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string, _ ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	resp := &clientv3.GetResponse{}
+	for k, v := range f.values {
+		if strings.HasPrefix(k, key) {
+			resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(k), Value: []byte(v)})
+		}
+	}
+	resp.Count = int64(len(resp.Kvs))
+
+	return resp, nil
+}
+
+func (f *fakeKV) Watch(context.Context, string, ...clientv3.OpOption) clientv3.WatchChan {
+	return f.events
+}
+
+func (f *fakeKV) put(key, value string) {
+	f.mu.Lock()
+	f.values[key] = value
+	f.mu.Unlock()
+
+	f.events <- clientv3.WatchResponse{
+		Events: []*clientv3.Event{{
+			Type: mvccpb.PUT,
+			Kv:   &mvccpb.KeyValue{Key: []byte(key), Value: []byte(value)},
+		}},
+	}
+}
+
+func TestLoaderLoadContext(t *testing.T) {
+	kv := newFakeKV(map[string]string{
+		"/services/billing/name":    "billing",
+		"/services/billing/db/host": "db.internal",
+		"/services/other/name":      "other",
+	})
+
+	loader, err := etcd.NewLoader[ServiceConfig](kv, "/services/billing/")
+	if err != nil {
+		t.Fatalf("failed to create etcd loader: %v", err)
+	}
+
+	cfg, err := loader.LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" || cfg.Database.Host != "db.internal" || cfg.Database.Port != 5432 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoaderEmptyPrefix(t *testing.T) {
+	loader, err := etcd.NewLoader[ServiceConfig](newFakeKV(map[string]string{}), "/services/missing/")
+	if err != nil {
+		t.Fatalf("failed to create etcd loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, etcd.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+}
+
+func TestLoaderWrapsClientErrors(t *testing.T) {
+	kv := newFakeKV(nil)
+	kv.err = context.DeadlineExceeded
+
+	loader, err := etcd.NewLoader[ServiceConfig](kv, "/services/billing/", etcd.WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("failed to create etcd loader: %v", err)
+	}
+
+	_, err = loader.Load()
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, etcd.ErrSourceNotFound) {
+		t.Errorf("expected wrapped deadline error, got %v", err)
+	}
+}
+
+func TestLoaderWatch(t *testing.T) {
+	kv := newFakeKV(map[string]string{"/services/billing/name": "billing"})
+
+	loader, err := etcd.NewLoader[ServiceConfig](kv, "/services/billing/")
+	if err != nil {
+		t.Fatalf("failed to create etcd loader: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := loader.Watch(ctx)
+
+	go kv.put("/services/billing/name", "billing-v2")
+
+	select {
+	case cfg := <-updates:
+		if cfg.Name != "billing-v2" {
+			t.Errorf("expected reloaded name billing-v2, got %q", cfg.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reloaded config")
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+func TestLoaderPing(t *testing.T) {
+	kv := newFakeKV(map[string]string{"/services/billing/name": "billing"})
+
+	loader, err := etcd.NewLoader[ServiceConfig](kv, "/services/billing/")
+	if err != nil {
+		t.Fatalf("failed to create etcd loader: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); err != nil {
+		t.Errorf("unexpected ping error: %v", err)
+	}
+
+	empty, err := etcd.NewLoader[ServiceConfig](kv, "/services/unknown/")
+	if err != nil {
+		t.Fatalf("failed to create etcd loader: %v", err)
+	}
+
+	if err := empty.Ping(context.Background()); !errors.Is(err, etcd.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	kv.err = errors.New("connection refused")
+	if err := loader.Ping(context.Background()); !errors.Is(err, kv.err) {
+		t.Errorf("expected wrapped client error, got %v", err)
+	}
+}
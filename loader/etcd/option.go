@@ -0,0 +1,35 @@
+package etcd
+
+import (
+	"errors"
+	"time"
+)
+
+// Options defines a set of functional options for the etcd loader
+type Options struct {
+	Timeout           time.Duration
+	WatchErrorHandler func(error)
+}
+
+// Option defines a functional option for the etcd loader
+type Option func(*Options) error
+
+// WithTimeout bounds every etcd request made by LoadContext
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Options) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+
+		opts.Timeout = timeout
+		return nil
+	}
+}
+
+// WithWatchErrorHandler sets a function that receives errors encountered by Watch
+func WithWatchErrorHandler(fn func(error)) Option {
+	return func(opts *Options) error {
+		opts.WatchErrorHandler = fn
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+// Package flags derives command-line flag definitions from configuration structs,
+// so that a single struct can describe both its environment variables and its flags.
+package flags
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ErrDuplicateFlag indicates that a flag name derived from a field is already defined on the FlagSet,
+// either by another field or by the application.
+var ErrDuplicateFlag = errors.New("flag already defined")
+
+// Register registers a flag on fs for every env-tagged field of T, descending into
+// nested structs and applying their envPrefix tags.
+//
+// The flag name is taken from the field's flag tag, or derived from its environment
+// key by lowercasing it and replacing underscores with dashes (DB_HOST becomes db-host).
+// The envDefault tag provides the flag default and the doc tag its usage text.
+// Scalar fields, time.Duration and encoding.TextUnmarshaler types get typed flags;
+// fields of any other type are registered as string flags holding the raw value.
+// A name that is already defined on fs fails with ErrDuplicateFlag instead of panicking.
+func Register[T any](fs *flag.FlagSet) error {
+	var firstErr error
+	(fields.Walker{}).Walk(reflect.TypeFor[T](), func(f fields.Field) {
+		if firstErr != nil {
+			return
+		}
+
+		if err := register(fs, f); err != nil {
+			firstErr = fmt.Errorf("error registering flag for field %s: %w", f.Name, err)
+		}
+	})
+
+	return firstErr
+}
+
+// flagName returns the flag name for a field with the given flag tag and environment key
+func flagName(tag, key string) string {
+	if tag != "" {
+		return tag
+	}
+
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+func register(fs *flag.FlagSet, f fields.Field) error {
+	name := flagName(f.Struct.Tag.Get("flag"), f.Key)
+	if fs.Lookup(name) != nil {
+		return fmt.Errorf("%w: -%s", ErrDuplicateFlag, name)
+	}
+
+	usage := f.Struct.Tag.Get("doc")
+	if usage == "" {
+		usage = "sets " + f.Key
+	}
+
+	t := f.Struct.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeFor[time.Duration]() {
+		def, err := parseDefault(f, time.ParseDuration)
+		if err != nil {
+			return err
+		}
+
+		fs.Duration(name, def, usage)
+		return nil
+	}
+
+	if reflect.PointerTo(t).Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) &&
+		t.Implements(reflect.TypeFor[encoding.TextMarshaler]()) {
+		p := reflect.New(t)
+		if f.HasDefault {
+			if err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(f.Default)); err != nil {
+				return fmt.Errorf("invalid default %q: %w", f.Default, err)
+			}
+		}
+
+		fs.TextVar(p.Interface().(encoding.TextUnmarshaler), name, p.Elem().Interface().(encoding.TextMarshaler), usage)
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		def, err := parseDefault(f, strconv.ParseBool)
+		if err != nil {
+			return err
+		}
+
+		fs.Bool(name, def, usage)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		def, err := parseDefault(f, strconv.Atoi)
+		if err != nil {
+			return err
+		}
+
+		fs.Int(name, def, usage)
+	case reflect.Int64:
+		def, err := parseDefault(f, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+		if err != nil {
+			return err
+		}
+
+		fs.Int64(name, def, usage)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		def, err := parseDefault(f, func(s string) (uint, error) {
+			v, err := strconv.ParseUint(s, 10, 0)
+			return uint(v), err
+		})
+		if err != nil {
+			return err
+		}
+
+		fs.Uint(name, def, usage)
+	case reflect.Uint64:
+		def, err := parseDefault(f, func(s string) (uint64, error) { return strconv.ParseUint(s, 10, 64) })
+		if err != nil {
+			return err
+		}
+
+		fs.Uint64(name, def, usage)
+	case reflect.Float32, reflect.Float64:
+		def, err := parseDefault(f, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+		if err != nil {
+			return err
+		}
+
+		fs.Float64(name, def, usage)
+	default:
+		fs.String(name, f.Default, usage)
+	}
+
+	return nil
+}
+
+// parseDefault parses the envDefault tag of f with parse, returning the zero value if there is none
+func parseDefault[V any](f fields.Field, parse func(string) (V, error)) (V, error) {
+	var zero V
+	if !f.HasDefault {
+		return zero, nil
+	}
+
+	v, err := parse(f.Default)
+	if err != nil {
+		return zero, fmt.Errorf("invalid default %q: %w", f.Default, err)
+	}
+
+	return v, nil
+}
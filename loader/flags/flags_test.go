@@ -0,0 +1,112 @@
+package flags_test
+
+import (
+	"errors"
+	"flag"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/loader/flags"
+)
+
+type ServerConfig struct {
+	AppName string        `env:"APP_NAME" envDefault:"myapp" doc:"application name"`
+	Port    int           `env:"PORT" envDefault:"8080" flag:"listen-port"`
+	Debug   bool          `env:"DEBUG"`
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"5s"`
+	Hosts   []string      `env:"HOSTS" envDefault:"a,b"`
+	Bind    netip.Addr    `env:"BIND" envDefault:"127.0.0.1"`
+
+	Database struct {
+		Host string `env:"HOST"`
+	} `envPrefix:"DB_"`
+}
+
+func TestRegister(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := flags.Register[ServerConfig](fs); err != nil {
+		t.Fatalf("unexpected error registering flags: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		defValue string
+		value    any
+	}{
+		{name: "app-name", defValue: "myapp", value: "myapp"},
+		{name: "listen-port", defValue: "8080", value: 8080},
+		{name: "debug", defValue: "false", value: false},
+		{name: "timeout", defValue: "5s", value: 5 * time.Second},
+		{name: "hosts", defValue: "a,b", value: "a,b"},
+		{name: "bind", defValue: "127.0.0.1", value: netip.MustParseAddr("127.0.0.1")},
+		{name: "db-host", defValue: "", value: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := fs.Lookup(tc.name)
+			if f == nil {
+				t.Fatalf("expected flag %q to be registered", tc.name)
+			}
+
+			if f.DefValue != tc.defValue {
+				t.Errorf("expected default %q, got %q", tc.defValue, f.DefValue)
+			}
+
+			getter, ok := f.Value.(flag.Getter)
+			if !ok {
+				t.Fatalf("flag %q does not implement flag.Getter", tc.name)
+			}
+
+			got := getter.Get()
+			if addr, ok := got.(*netip.Addr); ok {
+				got = *addr
+			}
+
+			if got != tc.value {
+				t.Errorf("expected value %#v (%T), got %#v (%T)", tc.value, tc.value, got, got)
+			}
+		})
+	}
+
+	if usage := fs.Lookup("app-name").Usage; usage != "application name" {
+		t.Errorf("expected usage from doc tag, got %q", usage)
+	}
+}
+
+func TestRegisterInvalidDefault(t *testing.T) {
+	type Config struct {
+		Port int `env:"PORT" envDefault:"not-a-number"`
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := flags.Register[Config](fs); err == nil {
+		t.Fatal("expected error for invalid default, got nil")
+	}
+}
+
+func TestRegisterDuplicateFlag(t *testing.T) {
+	type Config struct {
+		DBHost string `env:"DB_HOST"`
+
+		Database struct {
+			Host string `env:"HOST"`
+		} `envPrefix:"DB_"`
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := flags.Register[Config](fs); !errors.Is(err, flags.ErrDuplicateFlag) {
+		t.Fatalf("expected ErrDuplicateFlag for fields sharing a name, got %v", err)
+	}
+
+	type PortConfig struct {
+		Port int `env:"PORT"`
+	}
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("port", 0, "defined by the application")
+	if err := flags.Register[PortConfig](fs); !errors.Is(err, flags.ErrDuplicateFlag) {
+		t.Fatalf("expected ErrDuplicateFlag for an existing flag, got %v", err)
+	}
+}
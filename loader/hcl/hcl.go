@@ -0,0 +1,139 @@
+// Package hcl provides a configuration loader that decodes HCL files, the syntax used by
+// Terraform and other HashiCorp tools, into struct fields using github.com/hashicorp/hcl/v2.
+//
+// Fields are bound with gohcl tags: `hcl:"name"` for required attributes,
+// `hcl:"name,optional"` for optional ones and `hcl:"name,block"` for blocks, which map to
+// nested structs. Labelled blocks such as `listener "http" { ... }` decode into slices of
+// structs with a `hcl:"name,label"` field. Expressions are evaluated without variables or
+// functions, so only constant values are allowed.
+//
+// Files are decoded in order into the same value. Attributes missing from a later file keep
+// their earlier value when they are optional, so override files should only use optional
+// attributes and pointer or slice blocks. A pointer block present in a later file is decoded
+// into the existing struct, so only the attributes and nested blocks it sets change, while
+// a later slice of labelled blocks replaces the earlier slice as a whole. Invalid input fails
+// with a *goconfig.SourceError carrying the line and column of the first error.
+package hcl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrFilesNotSpecified indicates that the NewLoader function was called with an empty Files array.
+	ErrFilesNotSpecified = errors.New("hcl files not specified")
+
+	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+)
+
+// Loader implements configuration loading from HCL files
+type Loader[T any] struct {
+	Files   []string
+	Options Options
+}
+
+// NewLoader creates a new HCL config loader. Files are decoded in order and attributes
+// in later files override the same attributes in earlier ones.
+func NewLoader[T any](files []string, opts ...Option) (*Loader[T], error) {
+	if len(files) == 0 {
+		return nil, ErrFilesNotSpecified
+	}
+
+	loader := &Loader[T]{
+		Files: files,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.Options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	return loader, nil
+}
+
+// Load decodes the configured HCL files into a new T
+// It is equivalent to LoadContext(context.Background()).
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext is like Load but checks ctx before each file, returning ctx.Err() once it is done
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	var cfg T
+	for _, filename := range l.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := decodeFile(filename, &cfg); err != nil {
+			if l.Options.SkipMissingFiles && errors.Is(err, ErrSourceNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+	}
+
+	return &cfg, nil
+}
+
+// decodeFile parses a single HCL file and decodes its body into cfg
+func decodeFile[T any](filename string, cfg *T) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = ErrSourceNotFound
+		}
+
+		return fmt.Errorf("error loading hcl file %s: %w", filename, err)
+	}
+
+	file, diags := hclsyntax.ParseConfig(data, filename, hcl.InitialPos)
+	if !diags.HasErrors() {
+		diags = append(diags, gohcl.DecodeBody(file.Body, nil, cfg)...)
+	}
+
+	if diags.HasErrors() {
+		return fmt.Errorf("error loading hcl file: %w", sourceError(filename, data, diags))
+	}
+
+	return nil
+}
+
+// sourceError converts the first error diagnostic into a *goconfig.SourceError pointing at
+// its subject. The remaining diagnostics are dropped, as they often follow from the first.
+func sourceError(filename string, data []byte, diags hcl.Diagnostics) error {
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+
+		err := errors.New(diag.Summary)
+		if diag.Detail != "" {
+			err = fmt.Errorf("%s; %s", diag.Summary, diag.Detail)
+		}
+
+		if diag.Subject == nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+
+		srcErr := goconfig.NewSourceError(filename, data, diag.Subject.Start.Line, err)
+		srcErr.Column = diag.Subject.Start.Column
+
+		return srcErr
+	}
+
+	return fmt.Errorf("%s: %w", filename, diags)
+}
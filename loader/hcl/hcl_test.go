@@ -0,0 +1,292 @@
+package hcl_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/hcl"
+)
+
+type ListenerConfig struct {
+	Name    string `hcl:"name,label"`
+	Address string `hcl:"address"`
+}
+
+type ServiceConfig struct {
+	Name     string   `hcl:"name,optional"`
+	Features []string `hcl:"features,optional"`
+
+	Server *struct {
+		Host string `hcl:"host,optional"`
+		Port int    `hcl:"port,optional"`
+
+		TLS *struct {
+			Cert string `hcl:"cert"`
+		} `hcl:"tls,block"`
+	} `hcl:"server,block"`
+
+	Listeners []ListenerConfig `hcl:"listener,block"`
+}
+
+func createTempHCLFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.hcl")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write hcl file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoader(t *testing.T) {
+	file := createTempHCLFile(t, `
+name     = "billing"
+features = ["invoices", "refunds"]
+
+server {
+  host = "0.0.0.0"
+  port = 8080
+
+  tls {
+    cert = "/etc/tls/cert.pem"
+  }
+}
+
+listener "http" {
+  address = ":8080"
+}
+
+listener "grpc" {
+  address = ":9090"
+}
+`)
+
+	loader, err := hcl.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create hcl loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" || len(cfg.Features) != 2 {
+		t.Errorf("unexpected attributes: %+v", cfg)
+	}
+	if cfg.Server == nil || cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != 8080 {
+		t.Fatalf("expected server block to be decoded, got %+v", cfg.Server)
+	}
+	if cfg.Server.TLS == nil || cfg.Server.TLS.Cert != "/etc/tls/cert.pem" {
+		t.Errorf("expected nested tls block to be decoded, got %+v", cfg.Server.TLS)
+	}
+	if len(cfg.Listeners) != 2 || cfg.Listeners[1].Name != "grpc" || cfg.Listeners[1].Address != ":9090" {
+		t.Errorf("expected labelled blocks to be decoded, got %+v", cfg.Listeners)
+	}
+}
+
+func TestLoaderLaterFilesOverride(t *testing.T) {
+	base := createTempHCLFile(t, `
+name     = "base"
+features = ["invoices"]
+
+server {
+  host = "0.0.0.0"
+  port = 8080
+}
+`)
+	local := createTempHCLFile(t, `features = ["debug"]`)
+
+	loader, err := hcl.NewLoader[ServiceConfig]([]string{base, local})
+	if err != nil {
+		t.Fatalf("failed to create hcl loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "base" || cfg.Server == nil || cfg.Server.Port != 8080 {
+		t.Errorf("expected values absent from later files to be kept, got %+v", cfg)
+	}
+	if len(cfg.Features) != 1 || cfg.Features[0] != "debug" {
+		t.Errorf("expected later file to override features, got %v", cfg.Features)
+	}
+}
+
+func TestLoaderLaterBlocks(t *testing.T) {
+	base := createTempHCLFile(t, `
+server {
+  host = "0.0.0.0"
+  port = 8080
+
+  tls {
+    cert = "/etc/tls/cert.pem"
+  }
+}
+
+listener "http" {
+  address = ":8080"
+}
+
+listener "grpc" {
+  address = ":9090"
+}
+`)
+	local := createTempHCLFile(t, `
+server {
+  port = 9191
+}
+
+listener "admin" {
+  address = ":9999"
+}
+`)
+
+	loader, err := hcl.NewLoader[ServiceConfig]([]string{base, local})
+	if err != nil {
+		t.Fatalf("failed to create hcl loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Server == nil || cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != 9191 {
+		t.Errorf("expected pointer block to be merged into, got %+v", cfg.Server)
+	}
+	if cfg.Server.TLS == nil || cfg.Server.TLS.Cert != "/etc/tls/cert.pem" {
+		t.Errorf("expected nested block absent from the later file to be kept, got %+v", cfg.Server.TLS)
+	}
+	if len(cfg.Listeners) != 1 || cfg.Listeners[0].Name != "admin" {
+		t.Errorf("expected labelled blocks to be replaced, got %+v", cfg.Listeners)
+	}
+}
+
+func TestLoaderMissingFiles(t *testing.T) {
+	if _, err := hcl.NewLoader[ServiceConfig](nil); !errors.Is(err, hcl.ErrFilesNotSpecified) {
+		t.Errorf("expected ErrFilesNotSpecified, got %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.hcl")
+
+	loader, err := hcl.NewLoader[ServiceConfig]([]string{missing})
+	if err != nil {
+		t.Fatalf("failed to create hcl loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, hcl.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	file := createTempHCLFile(t, `name = "present"`)
+
+	loader, err = hcl.NewLoader[ServiceConfig]([]string{file, missing}, hcl.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create hcl loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "present" {
+		t.Errorf("expected Name present, got %q", cfg.Name)
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		line          int
+		column        int
+		errorContains string
+	}{
+		{
+			name:          "Syntax error",
+			content:       "name = \"billing\"\n\nserver {\n  port = = 8080\n}\n",
+			line:          4,
+			column:        10,
+			errorContains: "Invalid expression",
+		},
+		{
+			name:          "Type error",
+			content:       "name = \"billing\"\n\nserver {\n  port = \"notanumber\"\n}\n",
+			line:          4,
+			column:        11,
+			errorContains: "Unsuitable value type",
+		},
+		{
+			name:          "Unknown attribute",
+			content:       "name = \"billing\"\n\nserver {\n  prot = 8080\n}\n",
+			line:          4,
+			column:        3,
+			errorContains: "Unsupported argument",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := createTempHCLFile(t, tc.content)
+
+			loader, err := hcl.NewLoader[ServiceConfig]([]string{file})
+			if err != nil {
+				t.Fatalf("failed to create hcl loader: %v", err)
+			}
+
+			_, err = loader.Load()
+
+			var srcErr *goconfig.SourceError
+			if !errors.As(err, &srcErr) {
+				t.Fatalf("expected *goconfig.SourceError, got %T: %v", err, err)
+			}
+
+			if srcErr.File != file {
+				t.Errorf("expected file %s, got %s", file, srcErr.File)
+			}
+
+			if srcErr.Line != tc.line || srcErr.Column != tc.column {
+				t.Errorf("expected %d:%d, got %d:%d: %v", tc.line, tc.column, srcErr.Line, srcErr.Column, err)
+			}
+
+			if !strings.Contains(err.Error(), tc.errorContains) {
+				t.Errorf("expected error to contain %q, got %v", tc.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestLoaderLoadContext(t *testing.T) {
+	file := createTempHCLFile(t, `name = "billing"`)
+
+	loader, err := hcl.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create hcl loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewParallelLoader[ServiceConfig](loader).LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := loader.LoadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
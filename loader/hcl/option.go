@@ -0,0 +1,17 @@
+package hcl
+
+// Options defines a set of functional options for the HCL loader
+type Options struct {
+	SkipMissingFiles bool
+}
+
+// Option defines a functional option for the HCL loader
+type Option func(*Options) error
+
+// WithSkipMissingFiles configures the loader to skip missing HCL files
+func WithSkipMissingFiles() Option {
+	return func(opts *Options) error {
+		opts.SkipMissingFiles = true
+		return nil
+	}
+}
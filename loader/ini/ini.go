@@ -0,0 +1,152 @@
+// Package ini provides a configuration loader that reads INI files using
+// gopkg.in/ini.v1 and maps their keys onto struct fields.
+//
+// Fields are bound with `ini:"section.key"` tags; keys outside of any section are
+// bound by their bare name, e.g. `ini:"name"`. Nested structs are populated
+// recursively and may declare an iniPrefix tag (e.g. `iniPrefix:"server."`) so that
+// their fields can use keys relative to a section. Values are converted with the same
+// rules as the env loader, so every type supported there is supported here.
+// Malformed lines fail with a *goconfig.SourceError that shows the lines around them.
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	"gopkg.in/ini.v1"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrFilesNotSpecified indicates that the NewLoader function was called with an empty Files array.
+	ErrFilesNotSpecified = errors.New("ini files not specified")
+
+	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+)
+
+const (
+	tagName             = "ini"
+	prefixTagName       = "iniPrefix"
+	defaultValueTagName = "iniDefault"
+)
+
+// Loader implements configuration loading from INI files
+type Loader[T any] struct {
+	Files   []string
+	Options Options
+}
+
+// NewLoader creates a new INI config loader. Files are read in order and keys
+// in later files override the same keys in earlier ones.
+func NewLoader[T any](files []string, opts ...Option) (*Loader[T], error) {
+	if len(files) == 0 {
+		return nil, ErrFilesNotSpecified
+	}
+
+	loader := &Loader[T]{
+		Files: files,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.Options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	return loader, nil
+}
+
+// Load reads the configured INI files and maps their keys onto a new T
+func (l *Loader[T]) Load() (*T, error) {
+	values, err := l.loadFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg T
+	err = env.ParseWithOptions(&cfg, env.Options{
+		Environment:         values,
+		TagName:             tagName,
+		PrefixTagName:       prefixTagName,
+		DefaultValueTagName: defaultValueTagName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ini values into struct: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// loadFiles merges the configured files and flattens them into "section.key" values
+func (l *Loader[T]) loadFiles() (map[string]string, error) {
+	file := ini.Empty()
+
+	for _, filename := range l.Files {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				if l.Options.SkipMissingFiles {
+					continue
+				}
+
+				err = ErrSourceNotFound
+			}
+
+			return nil, fmt.Errorf("error loading ini file %s: %w", filename, err)
+		}
+
+		if err := file.Append(data); err != nil {
+			if line := errorLine(data, err); line > 0 {
+				return nil, fmt.Errorf("error loading ini file: %w", goconfig.NewSourceError(filename, data, line, err))
+			}
+
+			return nil, fmt.Errorf("error loading ini file %s: %w", filename, err)
+		}
+	}
+
+	values := make(map[string]string)
+	for _, section := range file.Sections() {
+		for _, key := range section.Keys() {
+			name := key.Name()
+			if section.Name() != ini.DefaultSection {
+				name = section.Name() + "." + name
+			}
+
+			values[name] = key.Value()
+		}
+	}
+
+	return values, nil
+}
+
+// errorLine returns the 1-based line of data that caused a parse error, or 0 if unknown.
+// The ini parser reports the offending line's content rather than its number.
+func errorLine(data []byte, err error) int {
+	var content string
+
+	var delimiterErr ini.ErrDelimiterNotFound
+	var emptyKeyErr ini.ErrEmptyKeyName
+	switch {
+	case errors.As(err, &delimiterErr):
+		content = delimiterErr.Line
+	case errors.As(err, &emptyKeyErr):
+		content = emptyKeyErr.Line
+	default:
+		return 0
+	}
+
+	content = strings.TrimSpace(content)
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == content {
+			return i + 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,157 @@
+package ini_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/ini"
+)
+
+type ServiceConfig struct {
+	Name string `ini:"name"`
+
+	Server struct {
+		Host    string        `ini:"host"`
+		Port    int           `ini:"port"`
+		Timeout time.Duration `ini:"timeout" iniDefault:"5s"`
+	} `iniPrefix:"server."`
+
+	Database struct {
+		Host string `ini:"database.host"`
+		User string `ini:"database.user"`
+	}
+}
+
+func createTempIniFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.ini")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write ini file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoaderSections(t *testing.T) {
+	file := createTempIniFile(t, `name = billing
+
+[server]
+host = 0.0.0.0
+port = 8080
+
+[database]
+host = db.internal
+user = billing
+`)
+
+	loader, err := ini.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create ini loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+	if cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != 8080 || cfg.Server.Timeout != 5*time.Second {
+		t.Errorf("unexpected server section: %+v", cfg.Server)
+	}
+	if cfg.Database.Host != "db.internal" || cfg.Database.User != "billing" {
+		t.Errorf("unexpected database section: %+v", cfg.Database)
+	}
+}
+
+func TestLoaderLaterFilesOverride(t *testing.T) {
+	base := createTempIniFile(t, "name = base\n[server]\nport = 8080\n")
+	local := createTempIniFile(t, "[server]\nport = 9090\n")
+
+	loader, err := ini.NewLoader[ServiceConfig]([]string{base, local})
+	if err != nil {
+		t.Fatalf("failed to create ini loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "base" || cfg.Server.Port != 9090 {
+		t.Errorf("expected name base and port 9090, got %q and %d", cfg.Name, cfg.Server.Port)
+	}
+}
+
+func TestLoaderMissingFiles(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.ini")
+
+	loader, err := ini.NewLoader[ServiceConfig]([]string{missing})
+	if err != nil {
+		t.Fatalf("failed to create ini loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, ini.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	loader, err = ini.NewLoader[ServiceConfig]([]string{missing}, ini.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create ini loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Errorf("unexpected error with skipped missing file: %v", err)
+	}
+}
+
+func TestLoaderParseErrorIncludesFilename(t *testing.T) {
+	file := createTempIniFile(t, "[server\nport = 8080\n")
+
+	loader, err := ini.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create ini loader: %v", err)
+	}
+
+	_, err = loader.Load()
+	if err == nil || !strings.Contains(err.Error(), file) {
+		t.Errorf("expected error mentioning %s, got %v", file, err)
+	}
+}
+
+func TestNewLoaderWithoutFiles(t *testing.T) {
+	if _, err := ini.NewLoader[ServiceConfig](nil); !errors.Is(err, ini.ErrFilesNotSpecified) {
+		t.Errorf("expected ErrFilesNotSpecified, got %v", err)
+	}
+}
+
+func TestLoaderParseErrorContext(t *testing.T) {
+	file := createTempIniFile(t, "name = billing\n\n[server]\nhost 0.0.0.0\nport = 8080\n")
+
+	loader, err := ini.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create ini loader: %v", err)
+	}
+
+	_, err = loader.Load()
+
+	var sourceErr *goconfig.SourceError
+	if !errors.As(err, &sourceErr) {
+		t.Fatalf("expected *goconfig.SourceError, got %v", err)
+	}
+
+	if sourceErr.Line != 4 {
+		t.Errorf("expected failure at line 4, got %d", sourceErr.Line)
+	}
+
+	if !strings.Contains(err.Error(), "  3 | [server]\n> 4 | host 0.0.0.0\n  5 | port = 8080") {
+		t.Errorf("expected context around line 4, got:\n%v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package ini
+
+// Options defines a set of functional options for the INI loader
+type Options struct {
+	SkipMissingFiles bool
+}
+
+// Option defines a functional option for the INI loader
+type Option func(*Options) error
+
+// WithSkipMissingFiles configures the loader to skip missing INI files
+func WithSkipMissingFiles() Option {
+	return func(opts *Options) error {
+		opts.SkipMissingFiles = true
+		return nil
+	}
+}
@@ -0,0 +1,117 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+// jsonFrame is an object or array the duplicate key scan is inside of
+type jsonFrame struct {
+	path    string
+	isArray bool
+	index   int
+
+	// keys maps the keys seen so far in an object to the offset just after each
+	keys      map[string]int64
+	expectKey bool
+	lastKey   string
+}
+
+// childPath returns the path of the value currently being read inside f
+func (f *jsonFrame) childPath() string {
+	if f.isArray {
+		return f.path + "[" + strconv.Itoa(f.index) + "]"
+	}
+
+	if f.path == "" {
+		return f.lastKey
+	}
+
+	return f.path + "." + f.lastKey
+}
+
+// checkDuplicateKeys reads filename token by token and returns a *goconfig.SourceError
+// wrapping ErrDuplicateKey for the first key set twice in the same object. Syntax errors
+// are left to the decoder, which reports them with more context.
+func checkDuplicateKeys(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	data = bytes.TrimPrefix(data, utf8BOM)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	var stack []*jsonFrame
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// io.EOF ends a valid file; anything else is a syntax error for Decode to report
+			return nil
+		}
+
+		var top *jsonFrame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if key, ok := token.(string); ok && top != nil && top.expectKey {
+			top.lastKey = key
+			top.expectKey = false
+
+			offset := decoder.InputOffset()
+			if first, ok := top.keys[key]; ok {
+				err := fmt.Errorf("%w %s, first set on line %d", ErrDuplicateKey, top.childPath(), lineAt(data, first))
+				return goconfig.NewSourceError(filename, data, lineAt(data, offset), err)
+			}
+
+			top.keys[key] = offset
+			continue
+		}
+
+		switch token {
+		case json.Delim('{'):
+			stack = append(stack, &jsonFrame{path: childPath(top), keys: map[string]int64{}, expectKey: true})
+		case json.Delim('['):
+			stack = append(stack, &jsonFrame{path: childPath(top), isArray: true})
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				valueDone(stack[len(stack)-1])
+			}
+		default:
+			valueDone(top)
+		}
+	}
+}
+
+// childPath returns the path of the value being read inside top, or "" at the top level
+func childPath(top *jsonFrame) string {
+	if top == nil {
+		return ""
+	}
+
+	return top.childPath()
+}
+
+// valueDone records that a value inside f has been read completely
+func valueDone(f *jsonFrame) {
+	switch {
+	case f == nil:
+	case f.isArray:
+		f.index++
+	default:
+		f.expectKey = true
+	}
+}
+
+// lineAt returns the 1-based line of offset in data
+func lineAt(data []byte, offset int64) int {
+	offset = min(max(offset, 0), int64(len(data)))
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,184 @@
+// Package json provides a configuration loader that decodes JSON files into struct fields
+// using encoding/json.
+//
+// Fields are bound with the usual `json:"key"` tags and nested structs map to nested objects.
+// Files are decoded in order into the same value, so later files override the keys they set:
+// nested objects are merged key by key, while arrays are replaced as a whole. Each file is
+// decoded straight from disk with a streaming decoder, but encoding/json buffers the whole
+// top-level value before decoding it, so memory use is close to reading the file first, as
+// BenchmarkLoad shows. A leading UTF-8 byte order mark is skipped.
+//
+// Decode failures are reported as a *goconfig.SourceError naming the file and line, and type
+// errors also name the JSON path of the offending value, e.g. server.port.
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrFilesNotSpecified indicates that the NewLoader function was called with an empty Files array.
+	ErrFilesNotSpecified = errors.New("json files not specified")
+
+	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+
+	// ErrDuplicateKey indicates that an object sets the same key twice, see WithRejectDuplicateKeys.
+	ErrDuplicateKey = errors.New("duplicate key")
+)
+
+// utf8BOM is the byte order mark some editors write at the start of UTF-8 files
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Loader implements configuration loading from JSON files
+type Loader[T any] struct {
+	Files   []string
+	Options Options
+}
+
+// NewLoader creates a new JSON config loader. Files are decoded in order and keys
+// in later files override the same keys in earlier ones.
+func NewLoader[T any](files []string, opts ...Option) (*Loader[T], error) {
+	if len(files) == 0 {
+		return nil, ErrFilesNotSpecified
+	}
+
+	loader := &Loader[T]{
+		Files: files,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.Options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	return loader, nil
+}
+
+// Load decodes the configured JSON files into a new T
+// It is equivalent to LoadContext(context.Background()).
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext is like Load but checks ctx before each file, returning ctx.Err() once it is done
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	var cfg T
+	for _, filename := range l.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := l.decodeFile(filename, &cfg); err != nil {
+			if l.Options.SkipMissingFiles && errors.Is(err, ErrSourceNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+	}
+
+	return &cfg, nil
+}
+
+// decodeFile decodes a single JSON file into cfg, which must hold a single top-level value
+func (l *Loader[T]) decodeFile(filename string, cfg *T) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = ErrSourceNotFound
+		}
+
+		return fmt.Errorf("error loading json file %s: %w", filename, err)
+	}
+	defer f.Close()
+
+	if l.Options.RejectDuplicateKeys {
+		if err := checkDuplicateKeys(filename); err != nil {
+			return fmt.Errorf("error loading json file: %w", err)
+		}
+	}
+
+	// Skip a leading UTF-8 byte order mark, which encoding/json rejects
+	r := bufio.NewReader(f)
+	var skipped int64
+	if prefix, err := r.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		n, _ := r.Discard(len(utf8BOM))
+		skipped = int64(n)
+	}
+
+	decoder := json.NewDecoder(r)
+	if l.Options.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(cfg); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("file is empty")
+		}
+
+		return fmt.Errorf("error loading json file: %w", sourceError(filename, skipped, decoder.InputOffset(), err))
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		err = errors.New("unexpected data after the top-level value")
+		return fmt.Errorf("error loading json file: %w", sourceError(filename, skipped, decoder.InputOffset(), err))
+	}
+
+	return nil
+}
+
+// unknownFieldPattern matches the error returned by encoding/json for unknown fields, which
+// carries no offset of its own
+var unknownFieldPattern = regexp.MustCompile(`^json: unknown field "(.+)"$`)
+
+// sourceError wraps a decode error in a *goconfig.SourceError pointing at the line of the
+// offending value, adding its JSON path for type errors. offset is relative to the decoder
+// input, which starts skipped bytes into the file. The file is only read again here, to show
+// the lines around the error.
+func sourceError(filename string, skipped, offset int64, err error) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+		if typeErr.Field != "" {
+			err = fmt.Errorf("at %s: %w", typeErr.Field, err)
+		}
+	}
+
+	offset += skipped
+
+	data, readErr := os.ReadFile(filename)
+	if readErr != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	// The decoder has consumed the whole enclosing object by the time it reports an unknown
+	// field, so point at the first occurrence of the key instead
+	if m := unknownFieldPattern.FindStringSubmatch(err.Error()); m != nil {
+		key := regexp.MustCompile(regexp.QuoteMeta(`"`+m[1]+`"`) + `\s*:`)
+		if loc := key.FindIndex(data); loc != nil {
+			offset = int64(loc[0])
+		}
+	}
+
+	return goconfig.NewSourceError(filename, data, lineAt(data, offset), err)
+}
@@ -0,0 +1,420 @@
+package json_test
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/json"
+)
+
+type ServiceConfig struct {
+	Name string `json:"name"`
+
+	Server struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	} `json:"server"`
+
+	Features []string          `json:"features"`
+	Labels   map[string]string `json:"labels"`
+}
+
+func createTempJSONFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write json file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoader(t *testing.T) {
+	file := createTempJSONFile(t, `{
+  "name": "billing",
+  "server": {"host": "0.0.0.0", "port": 8080},
+  "features": ["invoices", "refunds"],
+  "labels": {"team": "payments"}
+}`)
+
+	loader, err := json.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create json loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" || cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != 8080 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if len(cfg.Features) != 2 || cfg.Labels["team"] != "payments" {
+		t.Errorf("unexpected features or labels: %v %v", cfg.Features, cfg.Labels)
+	}
+}
+
+func TestLoaderLaterFilesOverride(t *testing.T) {
+	base := createTempJSONFile(t, `{
+  "name": "base",
+  "server": {"host": "0.0.0.0", "port": 8080},
+  "features": ["invoices", "refunds"],
+  "labels": {"team": "payments"}
+}`)
+	local := createTempJSONFile(t, `{"server": {"port": 9090}, "features": ["debug"], "labels": {"env": "local"}}`)
+
+	loader, err := json.NewLoader[ServiceConfig]([]string{base, local})
+	if err != nil {
+		t.Fatalf("failed to create json loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "base" || cfg.Server.Host != "0.0.0.0" {
+		t.Errorf("expected keys absent from later files to be kept, got %+v", cfg)
+	}
+	if cfg.Server.Port != 9090 {
+		t.Errorf("expected later file to override port, got %d", cfg.Server.Port)
+	}
+	if len(cfg.Features) != 1 || cfg.Features[0] != "debug" {
+		t.Errorf("expected arrays to be replaced, got %v", cfg.Features)
+	}
+	if cfg.Labels["team"] != "payments" || cfg.Labels["env"] != "local" {
+		t.Errorf("expected objects to be merged, got %v", cfg.Labels)
+	}
+}
+
+func TestLoaderMissingFiles(t *testing.T) {
+	if _, err := json.NewLoader[ServiceConfig](nil); !errors.Is(err, json.ErrFilesNotSpecified) {
+		t.Errorf("expected ErrFilesNotSpecified, got %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.json")
+
+	loader, err := json.NewLoader[ServiceConfig]([]string{missing})
+	if err != nil {
+		t.Fatalf("failed to create json loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, json.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	file := createTempJSONFile(t, `{"name": "present"}`)
+
+	loader, err = json.NewLoader[ServiceConfig]([]string{file, missing}, json.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create json loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "present" {
+		t.Errorf("expected Name present, got %q", cfg.Name)
+	}
+}
+
+func TestLoaderBOM(t *testing.T) {
+	content := "{\n  \"name\": \"billing\",\n  \"server\": {\"port\": 8080}\n}"
+
+	for name, data := range map[string]string{
+		"With BOM":    "\ufeff" + content,
+		"Without BOM": content,
+	} {
+		t.Run(name, func(t *testing.T) {
+			loader, err := json.NewLoader[ServiceConfig]([]string{createTempJSONFile(t, data)}, json.WithRejectDuplicateKeys())
+			if err != nil {
+				t.Fatalf("failed to create json loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			if cfg.Name != "billing" || cfg.Server.Port != 8080 {
+				t.Errorf("unexpected config: %+v", cfg)
+			}
+		})
+	}
+
+	t.Run("Error line after BOM", func(t *testing.T) {
+		file := createTempJSONFile(t, "\ufeff{\n  \"name\": \"billing\",\n  \"server\": {\"port\": \"x\"}\n}")
+
+		loader, err := json.NewLoader[ServiceConfig]([]string{file})
+		if err != nil {
+			t.Fatalf("failed to create json loader: %v", err)
+		}
+
+		_, err = loader.Load()
+
+		var srcErr *goconfig.SourceError
+		if !errors.As(err, &srcErr) || srcErr.Line != 3 {
+			t.Errorf("expected a *goconfig.SourceError at line 3, got %v", err)
+		}
+	})
+}
+
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		opts          []json.Option
+		line          int
+		errorContains []string
+	}{
+		{
+			name:          "Syntax error",
+			content:       "{\n  \"name\": \"billing\",\n  \"server\": {\"port\": 8080,}\n}",
+			line:          3,
+			errorContains: []string{"invalid character '}'"},
+		},
+		{
+			name:          "Type error",
+			content:       "{\n  \"name\": \"billing\",\n  \"server\": {\n    \"port\": \"notanumber\"\n  }\n}",
+			line:          4,
+			errorContains: []string{"at server.port", "cannot unmarshal string"},
+		},
+		{
+			name:          "Unknown field",
+			content:       "{\n  \"name\": \"billing\",\n  \"prot\": 8080\n}",
+			opts:          []json.Option{json.WithDisallowUnknownFields()},
+			line:          3,
+			errorContains: []string{`unknown field "prot"`},
+		},
+		{
+			name:          "Trailing data",
+			content:       "{\"name\": \"billing\"}\n{\"name\": \"other\"}",
+			line:          2,
+			errorContains: []string{"unexpected data after the top-level value"},
+		},
+		{
+			name:          "Empty file",
+			content:       "",
+			line:          1,
+			errorContains: []string{"file is empty"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := createTempJSONFile(t, tc.content)
+
+			loader, err := json.NewLoader[ServiceConfig]([]string{file}, tc.opts...)
+			if err != nil {
+				t.Fatalf("failed to create json loader: %v", err)
+			}
+
+			_, err = loader.Load()
+
+			var srcErr *goconfig.SourceError
+			if !errors.As(err, &srcErr) {
+				t.Fatalf("expected *goconfig.SourceError, got %T: %v", err, err)
+			}
+
+			if srcErr.File != file {
+				t.Errorf("expected file %s, got %s", file, srcErr.File)
+			}
+
+			if srcErr.Line != tc.line {
+				t.Errorf("expected line %d, got %d: %v", tc.line, srcErr.Line, err)
+			}
+
+			for _, want := range tc.errorContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoaderUnknownFieldsAllowedByDefault(t *testing.T) {
+	file := createTempJSONFile(t, `{"name": "billing", "prot": 8080}`)
+
+	loader, err := json.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create json loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("expected unknown keys to be ignored without WithDisallowUnknownFields, got %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+}
+
+func TestLoaderLoadContext(t *testing.T) {
+	file := createTempJSONFile(t, `{"name": "billing"}`)
+
+	loader, err := json.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create json loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewParallelLoader[ServiceConfig](loader).LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := loader.LoadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestLoaderRejectDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		key     string
+	}{
+		{
+			name:    "Top-level key",
+			content: "{\n  \"name\": \"billing\",\n  \"server\": {\"port\": 8080},\n  \"name\": \"other\"\n}",
+			line:    4,
+			key:     "name, first set on line 2",
+		},
+		{
+			name:    "Nested key",
+			content: "{\n  \"server\": {\n    \"host\": \"a\",\n    \"port\": 8080,\n    \"host\": \"b\"\n  }\n}",
+			line:    5,
+			key:     "server.host, first set on line 3",
+		},
+		{
+			name:    "Object in array",
+			content: "{\n  \"items\": [\n    {\"id\": 1},\n    {\"id\": 2,\n     \"id\": 3}\n  ]\n}",
+			line:    5,
+			key:     "items[1].id, first set on line 4",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := createTempJSONFile(t, tc.content)
+
+			loader, err := json.NewLoader[ServiceConfig]([]string{file}, json.WithRejectDuplicateKeys())
+			if err != nil {
+				t.Fatalf("failed to create json loader: %v", err)
+			}
+
+			_, err = loader.Load()
+			if !errors.Is(err, json.ErrDuplicateKey) {
+				t.Fatalf("expected ErrDuplicateKey, got %v", err)
+			}
+
+			var srcErr *goconfig.SourceError
+			if !errors.As(err, &srcErr) || srcErr.Line != tc.line {
+				t.Errorf("expected a *goconfig.SourceError at line %d, got %v", tc.line, err)
+			}
+
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Errorf("expected error to contain %q, got %v", tc.key, err)
+			}
+		})
+	}
+
+	t.Run("Same key in different objects", func(t *testing.T) {
+		file := createTempJSONFile(t, `{"name": "billing", "server": {"name": "api", "host": "h"}, "labels": {"host": "x"}}`)
+
+		loader, err := json.NewLoader[ServiceConfig]([]string{file}, json.WithRejectDuplicateKeys())
+		if err != nil {
+			t.Fatalf("failed to create json loader: %v", err)
+		}
+
+		if _, err := loader.Load(); err != nil {
+			t.Errorf("unexpected error loading config: %v", err)
+		}
+	})
+
+	t.Run("Default keeps the last value", func(t *testing.T) {
+		file := createTempJSONFile(t, `{"name": "billing", "name": "other"}`)
+
+		loader, err := json.NewLoader[ServiceConfig]([]string{file})
+		if err != nil {
+			t.Fatalf("failed to create json loader: %v", err)
+		}
+
+		cfg, err := loader.Load()
+		if err != nil {
+			t.Fatalf("unexpected error loading config: %v", err)
+		}
+
+		if cfg.Name != "other" {
+			t.Errorf("expected the last value to win, got %q", cfg.Name)
+		}
+	})
+}
+
+// BenchmarkLoad compares the loader, which decodes straight from the opened file, with
+// reading the whole file into memory and unmarshaling it
+func BenchmarkLoad(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString(`{"name": "billing", "server": {"host": "0.0.0.0", "port": 8080}, "features": [`)
+	for i := range 10000 {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, `"rule-%d"`, i)
+	}
+	sb.WriteString("]}")
+
+	path := filepath.Join(b.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o600); err != nil {
+		b.Fatalf("failed to write json file: %v", err)
+	}
+
+	b.Run("Decoder", func(b *testing.B) {
+		loader, err := json.NewLoader[ServiceConfig]([]string{path})
+		if err != nil {
+			b.Fatalf("failed to create json loader: %v", err)
+		}
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := loader.Load(); err != nil {
+				b.Fatalf("unexpected error loading config: %v", err)
+			}
+		}
+	})
+
+	b.Run("ReadFile", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				b.Fatalf("failed to read json file: %v", err)
+			}
+
+			var cfg ServiceConfig
+			if err := stdjson.Unmarshal(data, &cfg); err != nil {
+				b.Fatalf("unexpected error unmarshaling config: %v", err)
+			}
+		}
+	})
+}
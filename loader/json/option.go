@@ -0,0 +1,38 @@
+package json
+
+// Options defines a set of functional options for the JSON loader
+type Options struct {
+	SkipMissingFiles      bool
+	DisallowUnknownFields bool
+	RejectDuplicateKeys   bool
+}
+
+// Option defines a functional option for the JSON loader
+type Option func(*Options) error
+
+// WithSkipMissingFiles configures the loader to skip missing JSON files
+func WithSkipMissingFiles() Option {
+	return func(opts *Options) error {
+		opts.SkipMissingFiles = true
+		return nil
+	}
+}
+
+// WithDisallowUnknownFields makes Load fail when a file contains a key that matches no field
+// of T, catching typos such as "prot" for "port"
+func WithDisallowUnknownFields() Option {
+	return func(opts *Options) error {
+		opts.DisallowUnknownFields = true
+		return nil
+	}
+}
+
+// WithRejectDuplicateKeys makes Load fail when an object in a file sets the same key twice,
+// which encoding/json otherwise accepts by keeping the last value. The error names the key
+// and the lines of both occurrences.
+func WithRejectDuplicateKeys() Option {
+	return func(opts *Options) error {
+		opts.RejectDuplicateKeys = true
+		return nil
+	}
+}
@@ -0,0 +1,167 @@
+// Package k8sdir provides a configuration loader that reads Kubernetes ConfigMap and Secret
+// volume mounts, where every key of the object is a file whose content is the value.
+//
+// Fields are bound with the same `env` tags as the env loader. File names are normalized to
+// environment keys by uppercasing them and replacing '-' and '.' with '_', so a key named
+// db-host or DB_HOST both populate a field tagged `env:"DB_HOST"`. Trailing newlines of values
+// are trimmed. Hidden entries, such as the ..data symlink and the timestamped directories
+// Kubernetes uses for atomic updates, are skipped.
+package k8sdir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrDirNotSpecified indicates that the NewLoader function was called with an empty directory.
+	ErrDirNotSpecified = errors.New("mount directory not specified")
+
+	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+
+	// ErrKeyCollision indicates that two files normalize to the same key.
+	ErrKeyCollision = errors.New("key collision")
+)
+
+// keyReplacer maps characters allowed in ConfigMap keys but not in env keys to '_'
+var keyReplacer = strings.NewReplacer("-", "_", ".", "_")
+
+// Loader implements configuration loading from a Kubernetes ConfigMap or Secret mount
+type Loader[T any] struct {
+	Dir     string
+	Options Options
+}
+
+// NewLoader creates a new loader for the ConfigMap or Secret mounted at dir.
+// Several mounts can be combined with goconfig.NewParallelLoader.
+func NewLoader[T any](dir string, opts ...Option) (*Loader[T], error) {
+	if dir == "" {
+		return nil, ErrDirNotSpecified
+	}
+
+	loader := &Loader[T]{
+		Dir: dir,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.Options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	return loader, nil
+}
+
+// Load reads every key file of the mount directory and maps the values onto a new T.
+// It is equivalent to LoadContext(context.Background()).
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext is like Load but stops reading key files once ctx is done, returning ctx.Err()
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	values, err := l.readDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg T
+	if err := env.ParseWithOptions(&cfg, env.Options{Environment: values}); err != nil {
+		return nil, fmt.Errorf("error parsing mounted values into struct: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Ping checks that the mount directory exists and is readable, without reading its key files.
+// A missing directory passes when WithSkipMissingDir is set.
+func (l *Loader[T]) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f, err := os.Open(l.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if l.Options.SkipMissingDir {
+				return nil
+			}
+
+			err = ErrSourceNotFound
+		}
+
+		return fmt.Errorf("error checking mount directory %s: %w", l.Dir, err)
+	}
+
+	return f.Close()
+}
+
+// readDir reads the key files of the mount directory into a map of normalized keys to values
+func (l *Loader[T]) readDir(ctx context.Context) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(l.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if l.Options.SkipMissingDir {
+				return map[string]string{}, nil
+			}
+
+			err = ErrSourceNotFound
+		}
+
+		return nil, fmt.Errorf("error loading mount directory %s: %w", l.Dir, err)
+	}
+
+	values := make(map[string]string, len(entries))
+	names := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(l.Dir, entry.Name())
+
+		// Keys are usually symlinks into ..data, so follow them before checking for directories
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("error loading mounted key %s: %w", path, err)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error loading mounted key %s: %w", path, err)
+		}
+
+		key := strings.ToUpper(keyReplacer.Replace(entry.Name()))
+		if prev, exists := names[key]; exists {
+			return nil, fmt.Errorf("error loading mount directory %s: %w: %s and %s both map to %s",
+				l.Dir, ErrKeyCollision, prev, entry.Name(), key)
+		}
+
+		names[key] = entry.Name()
+		values[key] = strings.TrimRight(string(data), "\r\n")
+	}
+
+	return values, nil
+}
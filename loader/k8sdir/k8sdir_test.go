@@ -0,0 +1,185 @@
+package k8sdir_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/k8sdir"
+)
+
+type MountConfig struct {
+	LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
+	Port     int    `env:"PORT"`
+	DBHost   string `env:"DB_HOST"`
+	Password string `env:"DB_PASSWORD"`
+}
+
+// createMount lays out dir like a kubelet volume mount: the files live in a timestamped
+// directory, ..data links to it and every key is a symlink through ..data
+func createMount(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	versioned := filepath.Join(dir, "..2024_05_01_12_00_00.000000001")
+	if err := os.Mkdir(versioned, 0o755); err != nil {
+		t.Fatalf("failed to create versioned directory: %v", err)
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(versioned, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write key file: %v", err)
+		}
+	}
+
+	if err := os.Symlink(filepath.Base(versioned), filepath.Join(dir, "..data")); err != nil {
+		t.Fatalf("failed to link ..data: %v", err)
+	}
+
+	for name := range files {
+		if err := os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)); err != nil {
+			t.Fatalf("failed to link key %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+func TestLoaderMount(t *testing.T) {
+	dir := createMount(t, map[string]string{
+		"port":        "8080\n",
+		"db-host":     "db.internal\n",
+		"DB_PASSWORD": "s3cr3t",
+	})
+
+	loader, err := k8sdir.NewLoader[MountConfig](dir)
+	if err != nil {
+		t.Fatalf("failed to create loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	want := MountConfig{LogLevel: "info", Port: 8080, DBHost: "db.internal", Password: "s3cr3t"}
+	if *cfg != want {
+		t.Errorf("expected %+v, got %+v", want, *cfg)
+	}
+}
+
+func TestLoaderKeyCollision(t *testing.T) {
+	dir := createMount(t, map[string]string{
+		"db-host": "a",
+		"DB_HOST": "b",
+	})
+
+	loader, err := k8sdir.NewLoader[MountConfig](dir)
+	if err != nil {
+		t.Fatalf("failed to create loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, k8sdir.ErrKeyCollision) {
+		t.Fatalf("expected ErrKeyCollision, got %v", err)
+	}
+}
+
+func TestLoaderMissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+
+	loader, err := k8sdir.NewLoader[MountConfig](dir)
+	if err != nil {
+		t.Fatalf("failed to create loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, k8sdir.ErrSourceNotFound) {
+		t.Fatalf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	loader, err = k8sdir.NewLoader[MountConfig](dir, k8sdir.WithSkipMissingDir())
+	if err != nil {
+		t.Fatalf("failed to create loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.LogLevel != "info" {
+		t.Errorf("expected defaults to apply, got %+v", cfg)
+	}
+}
+
+func TestNewLoaderNoDir(t *testing.T) {
+	if _, err := k8sdir.NewLoader[MountConfig](""); !errors.Is(err, k8sdir.ErrDirNotSpecified) {
+		t.Fatalf("expected ErrDirNotSpecified, got %v", err)
+	}
+}
+
+func TestLoaderPing(t *testing.T) {
+	dir := createMount(t, map[string]string{"PORT": "8080"})
+
+	loader, err := k8sdir.NewLoader[MountConfig](dir)
+	if err != nil {
+		t.Fatalf("failed to create k8sdir loader: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); err != nil {
+		t.Errorf("unexpected ping error: %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	loader, err = k8sdir.NewLoader[MountConfig](missing)
+	if err != nil {
+		t.Fatalf("failed to create k8sdir loader: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); !errors.Is(err, k8sdir.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	loader, err = k8sdir.NewLoader[MountConfig](missing, k8sdir.WithSkipMissingDir())
+	if err != nil {
+		t.Fatalf("failed to create k8sdir loader: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); err != nil {
+		t.Errorf("expected missing directory to pass with WithSkipMissingDir, got %v", err)
+	}
+}
+
+func TestLoaderLoadContext(t *testing.T) {
+	configMap := createMount(t, map[string]string{"port": "8080", "db-host": "db.internal"})
+	secret := createMount(t, map[string]string{"db-password": "s3cr3t"})
+
+	configLoader, err := k8sdir.NewLoader[MountConfig](configMap)
+	if err != nil {
+		t.Fatalf("failed to create loader: %v", err)
+	}
+
+	secretLoader, err := k8sdir.NewLoader[MountConfig](secret)
+	if err != nil {
+		t.Fatalf("failed to create loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewParallelLoader[MountConfig](configLoader, secretLoader).LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Port != 8080 || cfg.DBHost != "db.internal" || cfg.Password != "s3cr3t" {
+		t.Errorf("expected values from both mounts, got %+v", cfg)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := configLoader.LoadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package k8sdir
+
+// Options defines a set of functional options for the Kubernetes mount loader
+type Options struct {
+	SkipMissingDir bool
+}
+
+// Option defines a functional option for the Kubernetes mount loader
+type Option func(*Options) error
+
+// WithSkipMissingDir configures the loader to load defaults when the mount directory does not exist,
+// e.g. when an optional ConfigMap is not mounted
+func WithSkipMissingDir() Option {
+	return func(opts *Options) error {
+		opts.SkipMissingDir = true
+		return nil
+	}
+}
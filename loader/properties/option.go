@@ -0,0 +1,17 @@
+package properties
+
+// Options defines a set of functional options for the .properties loader
+type Options struct {
+	SkipMissingFiles bool
+}
+
+// Option defines a functional option for the .properties loader
+type Option func(*Options) error
+
+// WithSkipMissingFiles configures the loader to skip missing .properties files
+func WithSkipMissingFiles() Option {
+	return func(opts *Options) error {
+		opts.SkipMissingFiles = true
+		return nil
+	}
+}
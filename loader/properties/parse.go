@@ -0,0 +1,163 @@
+package properties
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// parseError reports a failure at a 1-based line of a .properties document
+type parseError struct {
+	Line int
+	Err  error
+}
+
+func (e *parseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *parseError) Unwrap() error {
+	return e.Err
+}
+
+// parse reads the key/value pairs of a .properties document into values.
+// It follows the java.util.Properties format: # and ! start comments, a trailing
+// backslash continues a line, keys end at the first unescaped '=', ':' or whitespace,
+// and values support the \t, \n, \r, \f and \uXXXX escapes.
+func parse(data string, values map[string]string) error {
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// Join continuation lines, dropping leading whitespace of each continued line
+		for continues(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		if continues(line) {
+			line = line[:len(line)-1]
+		}
+
+		rawKey, rawValue := splitKeyValue(line)
+
+		key, err := unescape(rawKey)
+		if err != nil {
+			return &parseError{Line: lineNo, Err: err}
+		}
+
+		value, err := unescape(rawValue)
+		if err != nil {
+			return &parseError{Line: lineNo, Err: err}
+		}
+
+		values[key] = value
+	}
+
+	return nil
+}
+
+// continues reports whether line ends with an odd number of backslashes
+func continues(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+
+	return n%2 == 1
+}
+
+// splitKeyValue splits a logical line into its raw key and value
+func splitKeyValue(line string) (string, string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+
+		if strings.IndexByte("=: \t\f", line[i]) >= 0 {
+			end = i
+			break
+		}
+	}
+
+	key, rest := line[:end], strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	return key, rest
+}
+
+// unescape resolves the escape sequences of a raw key or value
+func unescape(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch c := s[i]; c {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			r, n, err := unescapeUnicode(s[i+1:])
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteRune(r)
+			i += n
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// unescapeUnicode decodes the hex digits following a \u escape, combining UTF-16
+// surrogate pairs written as two consecutive escapes. It returns the rune and the
+// number of bytes of s it consumed.
+func unescapeUnicode(s string) (rune, int, error) {
+	if len(s) < 4 {
+		return 0, 0, fmt.Errorf("invalid unicode escape %q", `\u`+s)
+	}
+
+	code, err := strconv.ParseUint(s[:4], 16, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid unicode escape %q", `\u`+s[:4])
+	}
+
+	r := rune(code)
+	if utf16.IsSurrogate(r) && len(s) >= 10 && s[4:6] == `\u` {
+		if low, err := strconv.ParseUint(s[6:10], 16, 16); err == nil {
+			if pair := utf16.DecodeRune(r, rune(low)); pair != utf8.RuneError {
+				return pair, 10, nil
+			}
+		}
+	}
+
+	return r, 4, nil
+}
@@ -0,0 +1,113 @@
+// Package properties provides a configuration loader that reads Java-style
+// .properties files and maps their keys onto struct fields.
+//
+// Fields are bound with `properties:"key"` tags. Dotted keys such as server.port
+// map onto nested structs either by using the full key in the tag or by declaring a
+// propertiesPrefix tag on the nested struct (e.g. `propertiesPrefix:"server."`).
+// Values are converted with the same rules as the env loader, so every type
+// supported there is supported here. Malformed files fail with a *goconfig.SourceError
+// that shows the lines around the failure.
+package properties
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/caarlos0/env/v11"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrFilesNotSpecified indicates that the NewLoader function was called with an empty Files array.
+	ErrFilesNotSpecified = errors.New("properties files not specified")
+
+	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+)
+
+const (
+	tagName             = "properties"
+	prefixTagName       = "propertiesPrefix"
+	defaultValueTagName = "propertiesDefault"
+)
+
+// Loader implements configuration loading from .properties files
+type Loader[T any] struct {
+	Files   []string
+	Options Options
+}
+
+// NewLoader creates a new .properties config loader. Files are read in order and keys
+// in later files override the same keys in earlier ones.
+func NewLoader[T any](files []string, opts ...Option) (*Loader[T], error) {
+	if len(files) == 0 {
+		return nil, ErrFilesNotSpecified
+	}
+
+	loader := &Loader[T]{
+		Files: files,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.Options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	return loader, nil
+}
+
+// Load reads the configured .properties files and maps their keys onto a new T
+func (l *Loader[T]) Load() (*T, error) {
+	values, err := l.loadFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg T
+	err = env.ParseWithOptions(&cfg, env.Options{
+		Environment:         values,
+		TagName:             tagName,
+		PrefixTagName:       prefixTagName,
+		DefaultValueTagName: defaultValueTagName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing properties into struct: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// loadFiles reads the configured files into a single map, later files taking precedence
+func (l *Loader[T]) loadFiles() (map[string]string, error) {
+	values := make(map[string]string)
+
+	for _, filename := range l.Files {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				if l.Options.SkipMissingFiles {
+					continue
+				}
+
+				err = ErrSourceNotFound
+			}
+
+			return nil, fmt.Errorf("error loading properties file %s: %w", filename, err)
+		}
+
+		if err := parse(string(data), values); err != nil {
+			var pe *parseError
+			if errors.As(err, &pe) {
+				err = goconfig.NewSourceError(filename, data, pe.Line, pe.Err)
+			}
+
+			return nil, fmt.Errorf("error loading properties file: %w", err)
+		}
+	}
+
+	return values, nil
+}
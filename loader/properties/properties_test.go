@@ -0,0 +1,146 @@
+package properties_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/properties"
+)
+
+type ServiceConfig struct {
+	Name     string   `properties:"app.name"`
+	Greeting string   `properties:"app.greeting"`
+	Hosts    []string `properties:"app.hosts"`
+
+	Server struct {
+		Host string `properties:"host"`
+		Port int    `properties:"port"`
+	} `propertiesPrefix:"server."`
+}
+
+func createTempPropertiesFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "app.properties")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write properties file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoaderContinuationsAndNestedKeys(t *testing.T) {
+	file := createTempPropertiesFile(t, `# service settings
+! also a comment
+app.name = billing
+app.greeting : Gr\u00fc\u00dfe aus K\u00f6ln
+app.hosts = a.internal,\
+            b.internal,\
+            c.internal
+server.host=0.0.0.0
+server.port 8080
+`)
+
+	loader, err := properties.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create properties loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+	if cfg.Greeting != "Grüße aus Köln" {
+		t.Errorf("expected unicode escapes to be decoded, got %q", cfg.Greeting)
+	}
+	if len(cfg.Hosts) != 3 || cfg.Hosts[2] != "c.internal" {
+		t.Errorf("expected continued line to yield 3 hosts, got %q", cfg.Hosts)
+	}
+	if cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != 8080 {
+		t.Errorf("unexpected server config: %+v", cfg.Server)
+	}
+}
+
+func TestLoaderLaterFilesOverride(t *testing.T) {
+	base := createTempPropertiesFile(t, "app.name=base\nserver.port=8080\n")
+	local := createTempPropertiesFile(t, "server.port=9090\n")
+
+	loader, err := properties.NewLoader[ServiceConfig]([]string{base, local})
+	if err != nil {
+		t.Fatalf("failed to create properties loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "base" || cfg.Server.Port != 9090 {
+		t.Errorf("expected name base and port 9090, got %q and %d", cfg.Name, cfg.Server.Port)
+	}
+}
+
+func TestLoaderMissingFiles(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.properties")
+
+	loader, err := properties.NewLoader[ServiceConfig]([]string{missing})
+	if err != nil {
+		t.Fatalf("failed to create properties loader: %v", err)
+	}
+
+	_, err = loader.Load()
+	if !errors.Is(err, properties.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	if !errors.Is(err, goconfig.ErrSourceNotFound) {
+		t.Errorf("expected the shared goconfig.ErrSourceNotFound, got %v", err)
+	}
+
+	loader, err = properties.NewLoader[ServiceConfig]([]string{missing}, properties.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create properties loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Errorf("unexpected error with skipped missing file: %v", err)
+	}
+}
+
+func TestLoaderParseErrorContext(t *testing.T) {
+	file := createTempPropertiesFile(t, `app.name = billing
+server.host = 0.0.0.0
+app.greeting = \u00zz
+server.port = 8080
+`)
+
+	loader, err := properties.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create properties loader: %v", err)
+	}
+
+	_, err = loader.Load()
+
+	var sourceErr *goconfig.SourceError
+	if !errors.As(err, &sourceErr) {
+		t.Fatalf("expected *goconfig.SourceError, got %v", err)
+	}
+
+	if sourceErr.File != file || sourceErr.Line != 3 {
+		t.Errorf("expected failure at %s:3, got %s:%d", file, sourceErr.File, sourceErr.Line)
+	}
+
+	for _, want := range []string{"  2 | server.host = 0.0.0.0", `> 3 | app.greeting = \u00zz`, "  4 | server.port = 8080"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+}
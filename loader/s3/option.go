@@ -0,0 +1,19 @@
+package s3
+
+import goconfig "github.com/nikita-shtimenko/goconfig"
+
+// Options defines a set of functional options for the S3 loader
+type Options struct {
+	Format goconfig.Format
+}
+
+// Option defines a functional option for the S3 loader
+type Option func(*Options) error
+
+// WithFormat sets the format of the object instead of inferring it from the key extension
+func WithFormat(format goconfig.Format) Option {
+	return func(opts *Options) error {
+		opts.Format = format
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+// Package s3 provides a configuration loader that downloads a configuration object
+// from AWS S3 and decodes it into a struct.
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrSourceNotFound indicates that the bucket or object does not exist.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+
+	// ErrUnsupportedFormat indicates that the object format is unknown or not supported.
+	// It is goconfig.ErrUnsupportedFormat.
+	ErrUnsupportedFormat = goconfig.ErrUnsupportedFormat
+)
+
+// S3GetObjectAPI is the subset of the S3 client used by the loader. *s3.Client implements it.
+type S3GetObjectAPI interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// Loader implements configuration loading from an S3 object
+type Loader[T any] struct {
+	client  S3GetObjectAPI
+	bucket  string
+	key     string
+	options Options
+}
+
+// NewLoader creates a config loader for the object key in bucket. The object format is
+// taken from WithFormat or, if unset, from the key extension.
+func NewLoader[T any](client S3GetObjectAPI, bucket, key string, opts ...Option) (*Loader[T], error) {
+	if client == nil {
+		return nil, errors.New("error creating loader: s3 client is nil")
+	}
+
+	if bucket == "" || key == "" {
+		return nil, errors.New("error creating loader: bucket and key are required")
+	}
+
+	loader := &Loader[T]{
+		client: client,
+		bucket: bucket,
+		key:    key,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	if _, err := loader.format(); err != nil {
+		return nil, fmt.Errorf("error creating loader: %w", err)
+	}
+
+	return loader, nil
+}
+
+// Load loads the configuration from S3
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext downloads and decodes the configuration object using ctx for the request.
+// A missing bucket or object is reported as ErrSourceNotFound.
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	out, err := l.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(l.bucket),
+		Key:    aws.String(l.key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			err = fmt.Errorf("%w: %w", ErrSourceNotFound, err)
+		}
+
+		return nil, fmt.Errorf("error loading s3 object s3://%s/%s: %w", l.bucket, l.key, err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading s3 object s3://%s/%s: %w", l.bucket, l.key, err)
+	}
+
+	// The format was validated by NewLoader
+	format, _ := l.format()
+
+	var cfg T
+	if err := goconfig.Unmarshal(data, format, &cfg); err != nil {
+		return nil, fmt.Errorf("error decoding s3 object s3://%s/%s: %w", l.bucket, l.key, err)
+	}
+
+	return &cfg, nil
+}
+
+// Ping checks that the configuration object exists and is readable by fetching only its
+// first byte. A missing bucket or object is reported as ErrSourceNotFound.
+func (l *Loader[T]) Ping(ctx context.Context) error {
+	out, err := l.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(l.bucket),
+		Key:    aws.String(l.key),
+		Range:  aws.String("bytes=0-0"),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			err = fmt.Errorf("%w: %w", ErrSourceNotFound, err)
+		}
+
+		return fmt.Errorf("error checking s3 object s3://%s/%s: %w", l.bucket, l.key, err)
+	}
+
+	return out.Body.Close()
+}
+
+// format returns the configured format, or the one implied by the key extension
+func (l *Loader[T]) format() (goconfig.Format, error) {
+	format := l.options.Format
+	if format == "" {
+		format = goconfig.Format(strings.TrimPrefix(strings.ToLower(path.Ext(l.key)), "."))
+		if format == "yml" {
+			format = goconfig.FormatYAML
+		}
+	}
+
+	switch format {
+	case goconfig.FormatJSON, goconfig.FormatYAML, goconfig.FormatTOML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w %q for key %s", ErrUnsupportedFormat, format, l.key)
+	}
+}
+
+// isNotFound reports whether err is an S3 error for a missing bucket or object
+func isNotFound(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.ErrorCode() {
+	case "NoSuchKey", "NoSuchBucket", "NotFound":
+		return true
+	default:
+		return false
+	}
+}
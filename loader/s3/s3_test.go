@@ -0,0 +1,140 @@
+package s3_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+	s3loader "github.com/nikita-shtimenko/goconfig/loader/s3"
+)
+
+type ServiceConfig struct {
+	Name     string `json:"name" yaml:"name" toml:"name"`
+	Database struct {
+		Host string `json:"host" yaml:"host" toml:"host"`
+		Port int    `json:"port" yaml:"port" toml:"port"`
+	} `json:"database" yaml:"database" toml:"database"`
+}
+
+// fakeS3 serves objects from memory, keyed by bucket/key
+type fakeS3 struct {
+	objects map[string]string
+}
+
+func (f *fakeS3) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	body, ok := f.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestLoaderJSONObject(t *testing.T) {
+	client := &fakeS3{objects: map[string]string{
+		"configs/billing/config.json": `{"name":"billing","database":{"host":"db.internal","port":5432}}`,
+	}}
+
+	loader, err := s3loader.NewLoader[ServiceConfig](client, "configs", "billing/config.json")
+	if err != nil {
+		t.Fatalf("failed to create s3 loader: %v", err)
+	}
+
+	cfg, err := loader.LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" || cfg.Database.Host != "db.internal" || cfg.Database.Port != 5432 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoaderStructuredFormats(t *testing.T) {
+	client := &fakeS3{objects: map[string]string{
+		"configs/config.yaml": "name: billing\ndatabase:\n  host: db.internal\n  port: 5432\n",
+		"configs/config.yml":  "name: billing\ndatabase:\n  host: db.internal\n  port: 5432\n",
+		"configs/config.toml": "name = \"billing\"\n\n[database]\nhost = \"db.internal\"\nport = 5432\n",
+	}}
+
+	for _, key := range []string{"config.yaml", "config.yml", "config.toml"} {
+		t.Run(key, func(t *testing.T) {
+			loader, err := s3loader.NewLoader[ServiceConfig](client, "configs", key)
+			if err != nil {
+				t.Fatalf("failed to create s3 loader: %v", err)
+			}
+
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading config: %v", err)
+			}
+
+			if cfg.Name != "billing" || cfg.Database.Host != "db.internal" || cfg.Database.Port != 5432 {
+				t.Errorf("unexpected config: %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestLoaderMissingObject(t *testing.T) {
+	loader, err := s3loader.NewLoader[ServiceConfig](&fakeS3{}, "configs", "missing.json")
+	if err != nil {
+		t.Fatalf("failed to create s3 loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, s3loader.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+}
+
+func TestLoaderFormat(t *testing.T) {
+	if _, err := s3loader.NewLoader[ServiceConfig](&fakeS3{}, "configs", "config.hcl"); !errors.Is(err, goconfig.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+
+	client := &fakeS3{objects: map[string]string{"configs/config": `{"name":"explicit"}`}}
+
+	loader, err := s3loader.NewLoader[ServiceConfig](client, "configs", "config", s3loader.WithFormat(goconfig.FormatJSON))
+	if err != nil {
+		t.Fatalf("failed to create s3 loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "explicit" {
+		t.Errorf("expected name explicit, got %q", cfg.Name)
+	}
+}
+
+func TestLoaderPing(t *testing.T) {
+	client := &fakeS3{objects: map[string]string{
+		"configs/billing/config.json": `{"name":"billing"}`,
+	}}
+
+	loader, err := s3loader.NewLoader[ServiceConfig](client, "configs", "billing/config.json")
+	if err != nil {
+		t.Fatalf("failed to create s3 loader: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); err != nil {
+		t.Errorf("unexpected ping error: %v", err)
+	}
+
+	missing, err := s3loader.NewLoader[ServiceConfig](client, "configs", "missing.json")
+	if err != nil {
+		t.Fatalf("failed to create s3 loader: %v", err)
+	}
+
+	if err := missing.Ping(context.Background()); !errors.Is(err, s3loader.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package sql
+
+import (
+	"errors"
+	"time"
+)
+
+// Options defines a set of functional options for the SQL loader
+type Options struct {
+	Args    []any
+	TagName string
+	Timeout time.Duration
+}
+
+// Option defines a functional option for the SQL loader
+type Option func(*Options) error
+
+// WithArgs sets the arguments for placeholders in the query, e.g. the service name for
+// "SELECT key, value FROM settings WHERE service = $1"
+func WithArgs(args ...any) Option {
+	return func(opts *Options) error {
+		opts.Args = args
+		return nil
+	}
+}
+
+// WithTagName binds fields with a custom struct tag instead of env, e.g. sql:"DB_HOST"
+func WithTagName(name string) Option {
+	return func(opts *Options) error {
+		if name == "" {
+			return errors.New("tag name is empty")
+		}
+
+		opts.TagName = name
+		return nil
+	}
+}
+
+// WithTimeout bounds every query made by LoadContext
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Options) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+
+		opts.Timeout = timeout
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+// Package sql provides a configuration loader that reads key/value rows from a database,
+// such as a settings table shared by many services.
+//
+// The query must return two columns, the key and the value, e.g.
+// "SELECT key, value FROM settings WHERE service = $1". Fields are bound with the same `env`
+// tags as the env loader, or with a custom tag set through WithTagName, and values are
+// converted with the same rules. Rows with a NULL value are treated as unset, so fields tagged
+// required fail to load when their key is missing from the result.
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/caarlos0/env/v11"
+)
+
+// Loader implements configuration loading from a SQL query
+type Loader[T any] struct {
+	db      *sql.DB
+	query   string
+	options Options
+}
+
+// NewLoader creates a config loader that runs query against db
+func NewLoader[T any](db *sql.DB, query string, opts ...Option) (*Loader[T], error) {
+	if db == nil {
+		return nil, errors.New("error creating loader: database is nil")
+	}
+
+	if query == "" {
+		return nil, errors.New("error creating loader: query is empty")
+	}
+
+	loader := &Loader[T]{
+		db:    db,
+		query: query,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	return loader, nil
+}
+
+// Load loads the configuration from the database
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext loads the configuration from the database, running the query with ctx so that
+// it is aborted when ctx is canceled or its deadline passes
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	if l.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.options.Timeout)
+		defer cancel()
+	}
+
+	values, err := l.readRows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg T
+	err = env.ParseWithOptions(&cfg, env.Options{
+		Environment: values,
+		TagName:     l.options.TagName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sql values into struct: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Ping checks that the database is reachable, without running the query
+func (l *Loader[T]) Ping(ctx context.Context) error {
+	if l.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.options.Timeout)
+		defer cancel()
+	}
+
+	if err := l.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("error checking database: %w", err)
+	}
+
+	return nil
+}
+
+// readRows runs the loader query and collects its rows into a map of keys to values
+func (l *Loader[T]) readRows(ctx context.Context) (map[string]string, error) {
+	rows, err := l.db.QueryContext(ctx, l.query, l.options.Args...)
+	if err != nil {
+		// Drivers report canceled queries with their own errors, so expose ctx.Err() as well
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("error querying config: %w: %w", ctxErr, err)
+		}
+
+		return nil, fmt.Errorf("error querying config: %w", err)
+	}
+	defer rows.Close()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var (
+			key   string
+			value sql.NullString
+		)
+
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("error reading config row: %w", err)
+		}
+
+		if value.Valid {
+			values[key] = value.String
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading config rows: %w", err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,237 @@
+package sql_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/nikita-shtimenko/goconfig/loader/sql"
+)
+
+const settingsQuery = "SELECT key, value FROM settings WHERE service = $1"
+
+type ServiceConfig struct {
+	DatabaseURL string        `env:"DATABASE_URL,required"`
+	Port        int           `env:"PORT" envDefault:"8080"`
+	Timeout     time.Duration `env:"TIMEOUT"`
+	Debug       bool          `env:"DEBUG"`
+}
+
+func TestLoader(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(settingsQuery)).
+		WithArgs("billing").
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).
+			AddRow("DATABASE_URL", "postgres://db.internal/billing").
+			AddRow("TIMEOUT", "5s").
+			AddRow("DEBUG", nil).
+			AddRow("UNRELATED", "ignored"))
+
+	loader, err := sql.NewLoader[ServiceConfig](db, settingsQuery, sql.WithArgs("billing"))
+	if err != nil {
+		t.Fatalf("failed to create sql loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	want := ServiceConfig{
+		DatabaseURL: "postgres://db.internal/billing",
+		Port:        8080,
+		Timeout:     5 * time.Second,
+	}
+	if *cfg != want {
+		t.Errorf("expected %+v, got %+v", want, *cfg)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet sqlmock expectations: %v", err)
+	}
+}
+
+func TestLoaderWithTagName(t *testing.T) {
+	type Config struct {
+		Host string `sql:"db.host"`
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT key, value FROM settings").
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).AddRow("db.host", "db.internal"))
+
+	loader, err := sql.NewLoader[Config](db, "SELECT key, value FROM settings", sql.WithTagName("sql"))
+	if err != nil {
+		t.Fatalf("failed to create sql loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Host != "db.internal" {
+		t.Errorf("Host: expected %q, got %q", "db.internal", cfg.Host)
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	errConnection := errors.New("connection refused")
+
+	tests := []struct {
+		name          string
+		expect        func(mock sqlmock.Sqlmock)
+		errorContains string
+		errorIs       error
+	}{
+		{
+			name: "Empty result for required field",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(settingsQuery)).WillReturnRows(sqlmock.NewRows([]string{"key", "value"}))
+			},
+			errorContains: "DATABASE_URL",
+		},
+		{
+			name: "Query error",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(settingsQuery)).WillReturnError(errConnection)
+			},
+			errorContains: "error querying config",
+			errorIs:       errConnection,
+		},
+		{
+			name: "Row error",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(settingsQuery)).WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).
+					AddRow("DATABASE_URL", "postgres://db").
+					RowError(0, errConnection))
+			},
+			errorContains: "error reading config rows",
+			errorIs:       errConnection,
+		},
+		{
+			name: "Invalid value",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(settingsQuery)).WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).
+					AddRow("DATABASE_URL", "postgres://db").
+					AddRow("PORT", "notanumber"))
+			},
+			errorContains: "error parsing sql values into struct",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %v", err)
+			}
+			defer db.Close()
+
+			tc.expect(mock)
+
+			loader, err := sql.NewLoader[ServiceConfig](db, settingsQuery)
+			if err != nil {
+				t.Fatalf("failed to create sql loader: %v", err)
+			}
+
+			_, err = loader.Load()
+			if err == nil || !strings.Contains(err.Error(), tc.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tc.errorContains, err)
+			}
+
+			if tc.errorIs != nil && !errors.Is(err, tc.errorIs) {
+				t.Errorf("expected error to wrap %v, got %v", tc.errorIs, err)
+			}
+		})
+	}
+}
+
+func TestLoaderLoadContextDeadline(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(settingsQuery)).
+		WillDelayFor(200 * time.Millisecond).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value"}))
+
+	loader, err := sql.NewLoader[ServiceConfig](db, settingsQuery)
+	if err != nil {
+		t.Fatalf("failed to create sql loader: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = loader.LoadContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestNewLoaderErrors(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := sql.NewLoader[ServiceConfig](nil, settingsQuery); err == nil {
+		t.Error("expected error for nil database")
+	}
+
+	if _, err := sql.NewLoader[ServiceConfig](db, ""); err == nil {
+		t.Error("expected error for empty query")
+	}
+
+	if _, err := sql.NewLoader[ServiceConfig](db, settingsQuery, sql.WithTimeout(0)); err == nil {
+		t.Error("expected error for non-positive timeout")
+	}
+}
+
+func TestLoaderPing(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	errUnreachable := errors.New("connection refused")
+	mock.ExpectPing()
+	mock.ExpectPing().WillReturnError(errUnreachable)
+
+	loader, err := sql.NewLoader[ServiceConfig](db, settingsQuery)
+	if err != nil {
+		t.Fatalf("failed to create sql loader: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); err != nil {
+		t.Errorf("unexpected ping error: %v", err)
+	}
+
+	if err := loader.Ping(context.Background()); !errors.Is(err, errUnreachable) {
+		t.Errorf("expected wrapped ping error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet sqlmock expectations: %v", err)
+	}
+}
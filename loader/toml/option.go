@@ -0,0 +1,49 @@
+package toml
+
+// Options defines a set of functional options for the TOML loader
+type Options struct {
+	SkipMissingFiles         bool
+	DisallowUnknownFields    bool
+	RequireSecurePermissions bool
+	SecretFields             []string
+}
+
+// Option defines a functional option for the TOML loader
+type Option func(*Options) error
+
+// WithSkipMissingFiles configures the loader to skip missing TOML files
+func WithSkipMissingFiles() Option {
+	return func(opts *Options) error {
+		opts.SkipMissingFiles = true
+		return nil
+	}
+}
+
+// WithDisallowUnknownFields makes Load fail when a file contains a key that matches no field
+// of T, catching typos such as prot = 8080 for port = 8080
+func WithDisallowUnknownFields() Option {
+	return func(opts *Options) error {
+		opts.DisallowUnknownFields = true
+		return nil
+	}
+}
+
+// WithRequireSecurePermissions makes Load fail with ErrInsecurePermissions when a file grants
+// any permission to its group or other users, e.g. mode 0644 or 0640. Use it for files
+// holding secrets, which should be 0600 or 0400.
+func WithRequireSecurePermissions() Option {
+	return func(opts *Options) error {
+		opts.RequireSecurePermissions = true
+		return nil
+	}
+}
+
+// WithSecretFields marks the fields at the given dotted Go paths, e.g. "Database.Password",
+// as secret in addition to fields tagged secret:"true", so that Dump masks them. NewLoader
+// fails if a path does not name a field of T.
+func WithSecretFields(paths ...string) Option {
+	return func(opts *Options) error {
+		opts.SecretFields = append(opts.SecretFields, paths...)
+		return nil
+	}
+}
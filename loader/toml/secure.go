@@ -0,0 +1,118 @@
+package toml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+// secretMask replaces the values of secret string fields in Dump, as in goconfig.Redact
+const secretMask = "******"
+
+// checkPermissions returns ErrInsecurePermissions if filename grants any permission to
+// group or other users
+func checkPermissions(filename string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrSourceNotFound
+		}
+
+		return err
+	}
+
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return fmt.Errorf("%w: mode %04o", ErrInsecurePermissions, perm)
+	}
+
+	return nil
+}
+
+// Dump encodes cfg as TOML with the values of secret fields masked: fields tagged
+// secret:"true" as for goconfig.Redact, and the fields named by WithSecretFields. Secret
+// strings become "******" and other secret values are zeroed. cfg itself is not modified.
+func (l *Loader[T]) Dump(cfg *T) ([]byte, error) {
+	redacted := goconfig.Redact(cfg)
+	if redacted == nil {
+		return nil, errors.New("error dumping config: config is nil")
+	}
+
+	root := reflect.ValueOf(redacted).Elem()
+	for _, path := range l.Options.SecretFields {
+		index, err := fieldIndex(root.Type(), path)
+		if err != nil {
+			return nil, fmt.Errorf("error dumping config: %w", err)
+		}
+
+		if v, ok := fieldByIndex(root, index); ok {
+			maskValue(v)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(redacted); err != nil {
+		return nil, fmt.Errorf("error dumping config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fieldIndex resolves the dotted Go path of a field of t, following pointers to structs
+func fieldIndex(t reflect.Type, path string) ([]int, error) {
+	var index []int
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("unknown secret field %q", path)
+		}
+
+		sf, ok := t.FieldByName(name)
+		if !ok || !sf.IsExported() || len(sf.Index) != 1 {
+			return nil, fmt.Errorf("unknown secret field %q", path)
+		}
+
+		index = append(index, sf.Index[0])
+		t = sf.Type
+	}
+
+	return index, nil
+}
+
+// fieldByIndex returns the field at index within v, reporting false at a nil pointer
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+
+	return v, true
+}
+
+// maskValue masks the secret value v in place
+func maskValue(v reflect.Value) {
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(secretMask)
+	case v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.String:
+		masked := reflect.New(v.Type().Elem())
+		masked.Elem().SetString(secretMask)
+		v.Set(masked)
+	default:
+		v.SetZero()
+	}
+}
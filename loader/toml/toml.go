@@ -0,0 +1,211 @@
+// Package toml provides a configuration loader that decodes TOML files into struct fields
+// using github.com/BurntSushi/toml, the parser also used by goconfig.Convert.
+//
+// Fields are bound with `toml:"key"` tags, and tables such as [server] or [server.tls] map
+// to nested structs. Files are decoded in order into the same value, so later files override
+// the keys they set, nested tables are merged key by key and arrays are replaced as a whole.
+// Syntax and type errors fail with a *goconfig.SourceError carrying the line and column of
+// the offending input; unknown keys rejected by WithDisallowUnknownFields are reported at the
+// line that sets them.
+//
+// For secret configs, WithRequireSecurePermissions rejects files other users can access and
+// Dump encodes a config with its secret fields masked.
+package toml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrFilesNotSpecified indicates that the NewLoader function was called with an empty Files array.
+	ErrFilesNotSpecified = errors.New("toml files not specified")
+
+	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+
+	// ErrInsecurePermissions indicates that a file grants permissions to its group or other
+	// users while WithRequireSecurePermissions is set.
+	ErrInsecurePermissions = errors.New("file is accessible by other users")
+)
+
+// Loader implements configuration loading from TOML files
+type Loader[T any] struct {
+	Files   []string
+	Options Options
+}
+
+// NewLoader creates a new TOML config loader. Files are decoded in order and keys
+// in later files override the same keys in earlier ones.
+func NewLoader[T any](files []string, opts ...Option) (*Loader[T], error) {
+	if len(files) == 0 {
+		return nil, ErrFilesNotSpecified
+	}
+
+	loader := &Loader[T]{
+		Files: files,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.Options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	for _, path := range loader.Options.SecretFields {
+		if _, err := fieldIndex(reflect.TypeFor[T](), path); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	return loader, nil
+}
+
+// Load decodes the configured TOML files into a new T
+// It is equivalent to LoadContext(context.Background()).
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext is like Load but checks ctx before each file, returning ctx.Err() once it is done
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	var cfg T
+	for _, filename := range l.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := l.decodeFile(filename, &cfg); err != nil {
+			if l.Options.SkipMissingFiles && errors.Is(err, ErrSourceNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+	}
+
+	return &cfg, nil
+}
+
+// decodeFile decodes a single TOML file into cfg
+func (l *Loader[T]) decodeFile(filename string, cfg *T) error {
+	if l.Options.RequireSecurePermissions {
+		if err := checkPermissions(filename); err != nil {
+			return fmt.Errorf("error loading toml file %s: %w", filename, err)
+		}
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = ErrSourceNotFound
+		}
+
+		return fmt.Errorf("error loading toml file %s: %w", filename, err)
+	}
+
+	meta, err := toml.NewDecoder(bytes.NewReader(data)).Decode(cfg)
+	if err != nil {
+		return fmt.Errorf("error loading toml file: %w", sourceError(filename, data, err))
+	}
+
+	if undecoded := meta.Undecoded(); l.Options.DisallowUnknownFields && len(undecoded) > 0 {
+		key := undecoded[0]
+		err := fmt.Errorf("unknown field %q", key.String())
+
+		line, column := keyPosition(data, key.String())
+		if line == 0 {
+			return fmt.Errorf("error loading toml file %s: %w", filename, err)
+		}
+
+		srcErr := goconfig.NewSourceError(filename, data, line, err)
+		srcErr.Column = column
+
+		return fmt.Errorf("error loading toml file: %w", srcErr)
+	}
+
+	return nil
+}
+
+// typeErrorPattern matches the line and last key that BurntSushi/toml puts in the message of
+// type errors, which are not returned as a ParseError
+var typeErrorPattern = regexp.MustCompile(`^toml: line (\d+) \(last key "(.*?)"\)`)
+
+// sourceError wraps a decode error in a *goconfig.SourceError pointing at the line and
+// column it reports. Type errors only carry a line, so the column of their key's value is
+// looked up.
+func sourceError(filename string, data []byte, err error) error {
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		srcErr := goconfig.NewSourceError(filename, data, parseErr.Position.Line, err)
+		srcErr.Column = parseErr.Position.Col
+
+		return srcErr
+	}
+
+	match := typeErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	line, _ := strconv.Atoi(match[1])
+
+	srcErr := goconfig.NewSourceError(filename, data, line, err)
+	if keyLine, _ := keyPosition(data, match[2]); keyLine == line {
+		text := strings.Split(string(data), "\n")[line-1]
+		if _, value, ok := strings.Cut(text, "="); ok {
+			srcErr.Column = len(text) - len(strings.TrimLeft(value, " \t")) + 1
+		}
+	}
+
+	return srcErr
+}
+
+// keyPosition returns the 1-based line and column of the table header or assignment that
+// defines the dotted key want, or 0, 0 if it cannot be found. BurntSushi/toml does not report positions
+// for undecoded keys, so the lines are scanned, tracking the current table.
+func keyPosition(data []byte, want string) (int, int) {
+	var table string
+	for i, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		column := len(line) - len(strings.TrimLeft(line, " \t")) + 1
+
+		if strings.HasPrefix(trimmed, "[") {
+			header, _, _ := strings.Cut(strings.TrimLeft(trimmed, "["), "]")
+			table = strings.TrimSpace(header)
+			if table == want {
+				return i + 1, column
+			}
+
+			continue
+		}
+
+		name, _, ok := strings.Cut(trimmed, "=")
+		if !ok || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		if table != "" {
+			name = table + "." + name
+		}
+
+		if name == want {
+			return i + 1, column
+		}
+	}
+
+	return 0, 0
+}
@@ -0,0 +1,330 @@
+package toml_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/toml"
+)
+
+type ServiceConfig struct {
+	Name string `toml:"name"`
+
+	Server struct {
+		Host string `toml:"host"`
+		Port int    `toml:"port"`
+
+		TLS struct {
+			Enabled bool   `toml:"enabled"`
+			Cert    string `toml:"cert"`
+		} `toml:"tls"`
+	} `toml:"server"`
+
+	Features []string          `toml:"features"`
+	Labels   map[string]string `toml:"labels"`
+}
+
+func createTempTOMLFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write toml file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoader(t *testing.T) {
+	file := createTempTOMLFile(t, `name = "billing"
+features = ["invoices", "refunds"]
+
+[server]
+host = "0.0.0.0"
+port = 8080
+
+[server.tls]
+enabled = true
+cert = "/etc/tls/cert.pem"
+
+[labels]
+team = "payments"
+`)
+
+	loader, err := toml.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create toml loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" || cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != 8080 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if !cfg.Server.TLS.Enabled || cfg.Server.TLS.Cert != "/etc/tls/cert.pem" {
+		t.Errorf("expected nested table to be decoded, got %+v", cfg.Server.TLS)
+	}
+	if len(cfg.Features) != 2 || cfg.Labels["team"] != "payments" {
+		t.Errorf("unexpected features or labels: %v %v", cfg.Features, cfg.Labels)
+	}
+}
+
+func TestLoaderLaterFilesOverride(t *testing.T) {
+	base := createTempTOMLFile(t, `name = "base"
+features = ["invoices", "refunds"]
+
+[server]
+host = "0.0.0.0"
+port = 8080
+
+[labels]
+team = "payments"
+`)
+	local := createTempTOMLFile(t, `features = ["debug"]
+
+[server]
+port = 9090
+
+[labels]
+env = "local"
+`)
+
+	loader, err := toml.NewLoader[ServiceConfig]([]string{base, local})
+	if err != nil {
+		t.Fatalf("failed to create toml loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "base" || cfg.Server.Host != "0.0.0.0" {
+		t.Errorf("expected keys absent from later files to be kept, got %+v", cfg)
+	}
+	if cfg.Server.Port != 9090 {
+		t.Errorf("expected later file to override port, got %d", cfg.Server.Port)
+	}
+	if len(cfg.Features) != 1 || cfg.Features[0] != "debug" {
+		t.Errorf("expected arrays to be replaced, got %v", cfg.Features)
+	}
+	if cfg.Labels["team"] != "payments" || cfg.Labels["env"] != "local" {
+		t.Errorf("expected tables to be merged, got %v", cfg.Labels)
+	}
+}
+
+func TestLoaderMissingFiles(t *testing.T) {
+	if _, err := toml.NewLoader[ServiceConfig](nil); !errors.Is(err, toml.ErrFilesNotSpecified) {
+		t.Errorf("expected ErrFilesNotSpecified, got %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.toml")
+
+	loader, err := toml.NewLoader[ServiceConfig]([]string{missing})
+	if err != nil {
+		t.Fatalf("failed to create toml loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, toml.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	file := createTempTOMLFile(t, `name = "present"`)
+
+	loader, err = toml.NewLoader[ServiceConfig]([]string{file, missing}, toml.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create toml loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "present" {
+		t.Errorf("expected Name present, got %q", cfg.Name)
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		opts    []toml.Option
+		line    int
+		column  int
+	}{
+		{
+			name:    "Syntax error",
+			content: "name = \"billing\"\n\n[server]\nport = = 8080\n",
+			line:    4,
+			column:  8,
+		},
+		{
+			name:    "Type error",
+			content: "name = \"billing\"\n\n[server]\nport = \"notanumber\"\n",
+			line:    4,
+			column:  8,
+		},
+		{
+			name:    "Unknown field",
+			content: "name = \"billing\"\n\n[server]\nprot = 8080\n",
+			opts:    []toml.Option{toml.WithDisallowUnknownFields()},
+			line:    4,
+			column:  1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := createTempTOMLFile(t, tc.content)
+
+			loader, err := toml.NewLoader[ServiceConfig]([]string{file}, tc.opts...)
+			if err != nil {
+				t.Fatalf("failed to create toml loader: %v", err)
+			}
+
+			_, err = loader.Load()
+
+			var srcErr *goconfig.SourceError
+			if !errors.As(err, &srcErr) {
+				t.Fatalf("expected *goconfig.SourceError, got %T: %v", err, err)
+			}
+
+			if srcErr.File != file {
+				t.Errorf("expected file %s, got %s", file, srcErr.File)
+			}
+
+			if srcErr.Line != tc.line || srcErr.Column != tc.column {
+				t.Errorf("expected %d:%d, got %d:%d: %v", tc.line, tc.column, srcErr.Line, srcErr.Column, err)
+			}
+
+			if !strings.Contains(err.Error(), "> 4 | ") {
+				t.Errorf("expected context marking the failing line, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoaderLoadContext(t *testing.T) {
+	file := createTempTOMLFile(t, `name = "billing"`)
+
+	loader, err := toml.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create toml loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewParallelLoader[ServiceConfig](loader).LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := loader.LoadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestLoaderRequireSecurePermissions(t *testing.T) {
+	file := createTempTOMLFile(t, `name = "billing"`)
+
+	loader, err := toml.NewLoader[ServiceConfig]([]string{file}, toml.WithRequireSecurePermissions())
+	if err != nil {
+		t.Fatalf("failed to create toml loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("expected a 0600 file to load, got %v", err)
+	}
+
+	if err := os.Chmod(file, 0o644); err != nil {
+		t.Fatalf("failed to chmod toml file: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, toml.ErrInsecurePermissions) {
+		t.Fatalf("expected ErrInsecurePermissions for a world-readable file, got %v", err)
+	}
+
+	loader, err = toml.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create toml loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Errorf("expected permissions to be ignored by default, got %v", err)
+	}
+}
+
+type SecretConfig struct {
+	Name     string `toml:"name"`
+	Token    string `toml:"token" secret:"true"`
+	Database struct {
+		User     string `toml:"user"`
+		Password string `toml:"password"`
+		Port     int    `toml:"port"`
+	} `toml:"database"`
+}
+
+func TestLoaderDump(t *testing.T) {
+	file := createTempTOMLFile(t, `name = "billing"
+token = "tok-123"
+
+[database]
+user = "app"
+password = "hunter2"
+port = 5432
+`)
+
+	loader, err := toml.NewLoader[SecretConfig]([]string{file}, toml.WithSecretFields("Database.Password", "Database.Port"))
+	if err != nil {
+		t.Fatalf("failed to create toml loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	out, err := loader.Dump(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error dumping config: %v", err)
+	}
+
+	dump := string(out)
+	for _, secret := range []string{"tok-123", "hunter2", "5432"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("expected %q to be masked, got:\n%s", secret, dump)
+		}
+	}
+
+	for _, visible := range []string{`name = "billing"`, `user = "app"`, `token = "******"`, `password = "******"`} {
+		if !strings.Contains(dump, visible) {
+			t.Errorf("expected %q in dump, got:\n%s", visible, dump)
+		}
+	}
+
+	if cfg.Token != "tok-123" || cfg.Database.Password != "hunter2" {
+		t.Error("expected Dump not to modify the config")
+	}
+}
+
+func TestNewLoaderUnknownSecretField(t *testing.T) {
+	_, err := toml.NewLoader[SecretConfig]([]string{"config.toml"}, toml.WithSecretFields("Database.Pasword"))
+	if err == nil || !strings.Contains(err.Error(), `unknown secret field "Database.Pasword"`) {
+		t.Fatalf("expected unknown secret field error, got %v", err)
+	}
+}
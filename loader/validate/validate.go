@@ -0,0 +1,146 @@
+// Package validate checks raw configuration values for common mistakes and reports them
+// with friendly errors that quote the offending value and name the field.
+//
+// The env parser reports a value like TIMEOUT=30 as a cryptic parse error. Running
+// Environment before (or after) loading pinpoints such footguns instead:
+//
+//   - time.Duration fields must parse with time.ParseDuration, which requires a unit ("30s")
+//   - fields tagged validate:"url" must hold absolute URLs with a scheme and host
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// ErrInvalidValue indicates that a raw configuration value failed validation.
+var ErrInvalidValue = errors.New("invalid config value")
+
+// FieldError describes a raw value that failed validation
+type FieldError struct {
+	// Field is the dotted Go path of the field, e.g. "Database.Timeout"
+	Field string
+
+	// Key is the environment key the value was read from
+	Key string
+
+	// Value is the raw value
+	Value string
+
+	// Err describes why the value is invalid
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s (%s): invalid value %q: %v", e.Field, e.Key, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrInvalidValue, e.Err}
+}
+
+// Options defines a set of functional options for Environment
+type Options struct {
+	Prefix string
+}
+
+// Option defines a functional option for Environment
+type Option func(*Options)
+
+// WithPrefix validates keys with the given prefix, matching a loader configured with the same prefix
+func WithPrefix(prefix string) Option {
+	return func(opts *Options) {
+		opts.Prefix = prefix
+	}
+}
+
+// Environment validates the raw values in environ of every env-tagged field of T.
+// If environ is nil, the process environment is used. Unset keys are not checked.
+// Every failure is reported as a *FieldError; multiple failures are joined.
+func Environment[T any](environ map[string]string, opts ...Option) error {
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if environ == nil {
+		environ = make(map[string]string)
+		for _, kv := range os.Environ() {
+			if key, value, ok := strings.Cut(kv, "="); ok {
+				environ[key] = value
+			}
+		}
+	}
+
+	var errs []error
+	(fields.Walker{Prefix: options.Prefix}).Walk(reflect.TypeFor[T](), func(f fields.Field) {
+		value, ok := environ[f.Key]
+		if !ok {
+			return
+		}
+
+		if err := checkValue(f, value); err != nil {
+			errs = append(errs, &FieldError{Field: f.Name, Key: f.Key, Value: value, Err: err})
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// checkValue validates a single raw value against the type and validate tag of f
+func checkValue(f fields.Field, value string) error {
+	t := f.Struct.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeFor[time.Duration]() {
+		if err := Duration(value); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range strings.Split(f.Struct.Tag.Get("validate"), ",") {
+		if strings.TrimSpace(rule) == "url" {
+			if err := URL(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Duration reports whether s parses as a time.Duration, with a hint for values missing a unit
+func Duration(s string) error {
+	if _, err := time.ParseDuration(s); err != nil {
+		if strings.Trim(s, "0123456789.") == "" && s != "" {
+			return fmt.Errorf("duration is missing a unit, e.g. %q", s+"s")
+		}
+
+		return fmt.Errorf("not a duration, expected a value like \"30s\" or \"1m30s\"")
+	}
+
+	return nil
+}
+
+// URL reports whether s is an absolute URL with a scheme and host
+func URL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return errors.New("not a valid URL")
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return errors.New("not an absolute URL, expected a value like \"https://example.com\"")
+	}
+
+	return nil
+}
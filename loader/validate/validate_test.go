@@ -0,0 +1,82 @@
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig/loader/validate"
+)
+
+type ClientConfig struct {
+	BaseURL string        `env:"BASE_URL" validate:"url"`
+	Timeout time.Duration `env:"TIMEOUT"`
+
+	Retry struct {
+		Backoff *time.Duration `env:"BACKOFF"`
+	} `envPrefix:"RETRY_"`
+}
+
+func TestEnvironmentValid(t *testing.T) {
+	environ := map[string]string{
+		"BASE_URL":      "https://api.example.com/v1",
+		"TIMEOUT":       "30s",
+		"RETRY_BACKOFF": "250ms",
+	}
+
+	if err := validate.Environment[ClientConfig](environ); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
+
+func TestEnvironmentInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ map[string]string
+		want    []string
+	}{
+		{
+			name:    "Duration without unit",
+			environ: map[string]string{"TIMEOUT": "30"},
+			want:    []string{"field Timeout (TIMEOUT)", `"30"`, `"30s"`},
+		},
+		{
+			name:    "Nested duration",
+			environ: map[string]string{"RETRY_BACKOFF": "soon"},
+			want:    []string{"field Retry.Backoff (RETRY_BACKOFF)", `"soon"`},
+		},
+		{
+			name:    "Relative URL",
+			environ: map[string]string{"BASE_URL": "not a url"},
+			want:    []string{"field BaseURL (BASE_URL)", `"not a url"`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate.Environment[ClientConfig](tc.environ)
+			if !errors.Is(err, validate.ErrInvalidValue) {
+				t.Fatalf("expected ErrInvalidValue, got %v", err)
+			}
+
+			var fieldErr *validate.FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("expected *validate.FieldError, got %T", err)
+			}
+
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %s, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestEnvironmentWithPrefix(t *testing.T) {
+	err := validate.Environment[ClientConfig](map[string]string{"SVC_TIMEOUT": "5"}, validate.WithPrefix("SVC_"))
+	if !errors.Is(err, validate.ErrInvalidValue) {
+		t.Errorf("expected ErrInvalidValue for prefixed key, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package xml
+
+// Options defines a set of functional options for the XML loader
+type Options struct {
+	SkipMissingFiles bool
+	MergeFiles       bool
+	Strict           bool
+}
+
+// Option defines a functional option for the XML loader
+type Option func(*Options) error
+
+// WithSkipMissingFiles configures the loader to skip missing XML files
+func WithSkipMissingFiles() Option {
+	return func(opts *Options) error {
+		opts.SkipMissingFiles = true
+		return nil
+	}
+}
+
+// WithMergeFiles allows several files to be passed to NewLoader. They are decoded in order
+// into the same value, so later files override the elements they set, including with zero
+// values such as <debug>false</debug>, nested elements are merged field by field and
+// repeated elements replace the earlier ones as a whole.
+func WithMergeFiles() Option {
+	return func(opts *Options) error {
+		opts.MergeFiles = true
+		return nil
+	}
+}
+
+// WithStrict makes Load fail when a file contains an element or attribute that matches no
+// field of T, catching typos such as <prot> for <port>. Fields tagged ",any", ",any,attr" or
+// ",innerxml" accept any content at their level.
+func WithStrict() Option {
+	return func(opts *Options) error {
+		opts.Strict = true
+		return nil
+	}
+}
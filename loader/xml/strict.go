@@ -0,0 +1,213 @@
+package xml
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	unmarshalerType     = reflect.TypeFor[xml.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// element lists the child elements and attributes accepted inside an element that is
+// decoded into a struct. A nil *element accepts any content.
+type element struct {
+	children map[string]*element
+	attrs    map[string]bool
+	anyChild bool
+	anyAttr  bool
+}
+
+func newElement() *element {
+	return &element{
+		children: make(map[string]*element),
+		attrs:    make(map[string]bool),
+	}
+}
+
+// add registers child under the element path, e.g. ["server", "tls"] for `xml:"server>tls"`
+func (el *element) add(path []string, child *element) {
+	if len(path) == 1 {
+		el.children[path[0]] = child
+		return
+	}
+
+	next, ok := el.children[path[0]]
+	if !ok {
+		next = newElement()
+		el.children[path[0]] = next
+	}
+
+	if next != nil {
+		next.add(path[1:], child)
+	}
+}
+
+// schemaFor returns the element accepted by values of type t
+func schemaFor(t reflect.Type) *element {
+	return buildSchema(t, make(map[reflect.Type]*element))
+}
+
+func buildSchema(t reflect.Type, seen map[reflect.Type]*element) *element {
+	for t.Kind() == reflect.Pointer || (t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8) {
+		t = t.Elem()
+	}
+
+	// Leaf values and types with custom decoding accept any content
+	if t.Kind() != reflect.Struct || isUnmarshaler(t) {
+		return nil
+	}
+
+	if el, ok := seen[t]; ok {
+		return el
+	}
+
+	el := newElement()
+	seen[t] = el
+	addFields(el, t, seen)
+
+	return el
+}
+
+// addFields registers the elements and attributes bound by the fields of the struct type t,
+// following the field rules of encoding/xml
+func addFields(el *element, t reflect.Type, seen map[reflect.Type]*element) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("xml")
+		if tag == "-" || f.Name == "XMLName" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+
+		name, flags, _ := strings.Cut(tag, ",")
+		if i := strings.LastIndex(name, " "); i >= 0 {
+			// Drop the namespace of "namespace-URL name" tags
+			name = name[i+1:]
+		}
+
+		hasFlag := func(flag string) bool {
+			return slices.Contains(strings.Split(flags, ","), flag)
+		}
+
+		fieldType := f.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		switch {
+		case hasFlag("attr"):
+			if hasFlag("any") {
+				el.anyAttr = true
+				continue
+			}
+
+			if name == "" {
+				name = f.Name
+			}
+
+			el.attrs[name] = true
+		case hasFlag("any"), hasFlag("innerxml"):
+			el.anyChild = true
+		case hasFlag("chardata"), hasFlag("cdata"), hasFlag("comment"):
+		case f.Anonymous && name == "" && fieldType.Kind() == reflect.Struct:
+			addFields(el, fieldType, seen)
+		default:
+			if name == "" {
+				name = f.Name
+			}
+
+			el.add(strings.Split(name, ">"), buildSchema(f.Type, seen))
+		}
+	}
+}
+
+func isUnmarshaler(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	return t.Implements(unmarshalerType) || ptr.Implements(unmarshalerType) ||
+		t.Implements(textUnmarshalerType) || ptr.Implements(textUnmarshalerType)
+}
+
+// unknownError reports an element or attribute that matches no field of the config
+type unknownError struct {
+	msg    string
+	line   int
+	column int
+}
+
+func (e *unknownError) Error() string {
+	return e.msg
+}
+
+// checkUnknown walks the first top-level element of data and reports the first element or
+// attribute not accepted by root. data must already have been decoded successfully.
+func checkUnknown(data []byte, root *element) *unknownError {
+	type open struct {
+		name string
+		el   *element
+	}
+
+	var stack []open
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		// Before reading a token, the decoder position is the start of that token
+		line, column := decoder.InputPos()
+
+		token, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			el := root
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+
+				el = nil
+				if parent.el != nil {
+					child, ok := parent.el.children[t.Name.Local]
+					if !ok && !parent.el.anyChild {
+						return &unknownError{
+							msg:    fmt.Sprintf("unknown element <%s> in <%s>", t.Name.Local, parent.name),
+							line:   line,
+							column: column,
+						}
+					}
+
+					el = child
+				}
+			}
+
+			if el != nil && !el.anyAttr {
+				for _, attr := range t.Attr {
+					if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" || el.attrs[attr.Name.Local] {
+						continue
+					}
+
+					return &unknownError{
+						msg:    fmt.Sprintf("unknown attribute %q on <%s>", attr.Name.Local, t.Name.Local),
+						line:   line,
+						column: column,
+					}
+				}
+			}
+
+			stack = append(stack, open{name: t.Name.Local, el: el})
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+
+			// Only the first top-level element is decoded
+			if len(stack) == 0 {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Package xml provides a configuration loader that decodes XML files into struct fields
+// using encoding/xml.
+//
+// Fields are bound with the usual `xml:"name"` tags, including `xml:"name,attr"` for
+// attributes and `xml:"a>b"` paths, and nested structs map to nested elements. Decode
+// failures are reported as a *goconfig.SourceError naming the file and line; unknown
+// elements found in strict mode also carry the column.
+package xml
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrFilesNotSpecified indicates that the NewLoader function was called with an empty Files array.
+	ErrFilesNotSpecified = errors.New("xml files not specified")
+
+	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+)
+
+// Loader implements configuration loading from XML files
+type Loader[T any] struct {
+	Files   []string
+	Options Options
+}
+
+// NewLoader creates a new XML config loader. Passing more than one file requires
+// WithMergeFiles, which decodes them in order so that later files override earlier ones.
+func NewLoader[T any](files []string, opts ...Option) (*Loader[T], error) {
+	if len(files) == 0 {
+		return nil, ErrFilesNotSpecified
+	}
+
+	loader := &Loader[T]{
+		Files: files,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.Options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	if len(files) > 1 && !loader.Options.MergeFiles {
+		return nil, errors.New("error creating loader: multiple xml files require WithMergeFiles")
+	}
+
+	return loader, nil
+}
+
+// Load decodes the configured XML files into a new T
+// It is equivalent to LoadContext(context.Background()).
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext is like Load but checks ctx before each file, returning ctx.Err() once it is done
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	var cfg T
+	for _, filename := range l.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := l.decodeFile(filename, &cfg); err != nil {
+			if l.Options.SkipMissingFiles && errors.Is(err, ErrSourceNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+	}
+
+	return &cfg, nil
+}
+
+// decodeFile decodes a single XML file into cfg, checking it for unknown elements and
+// attributes in strict mode
+func (l *Loader[T]) decodeFile(filename string, cfg *T) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = ErrSourceNotFound
+		}
+
+		return fmt.Errorf("error loading xml file %s: %w", filename, err)
+	}
+
+	// encoding/xml appends repeated elements to slices, so clear them to have this file
+	// replace rather than extend them
+	restore := resetSlices(reflect.ValueOf(cfg).Elem())
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(cfg); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("file is empty")
+		}
+
+		// Only syntax errors carry a line; for others, the decoder stops at the end of the
+		// element holding the bad value
+		line, _ := decoder.InputPos()
+
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line = syntaxErr.Line
+		}
+
+		return fmt.Errorf("error loading xml file: %w", goconfig.NewSourceError(filename, data, line, err))
+	}
+
+	restore()
+
+	if l.Options.Strict {
+		if err := checkUnknown(data, schemaFor(reflect.TypeFor[T]())); err != nil {
+			srcErr := goconfig.NewSourceError(filename, data, err.line, err)
+			srcErr.Column = err.column
+
+			return fmt.Errorf("error loading xml file: %w", srcErr)
+		}
+	}
+
+	return nil
+}
+
+// resetSlices sets every slice field reachable from v through structs and pointers to nil.
+// The returned function restores the previous value of the fields that are still nil, i.e.
+// those the decoded file did not set.
+func resetSlices(v reflect.Value) func() {
+	type reset struct {
+		field reflect.Value
+		value reflect.Value
+	}
+
+	var resets []reset
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Struct:
+			for i := range v.NumField() {
+				if v.Type().Field(i).IsExported() {
+					walk(v.Field(i))
+				}
+			}
+		case reflect.Slice:
+			if !v.IsNil() && v.CanSet() {
+				resets = append(resets, reset{field: v, value: reflect.ValueOf(v.Interface())})
+				v.SetZero()
+			}
+		}
+	}
+	walk(v)
+
+	return func() {
+		for _, r := range resets {
+			if r.field.IsNil() {
+				r.field.Set(r.value)
+			}
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package xml_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/xml"
+)
+
+type ServiceConfig struct {
+	Name    string `xml:"name"`
+	Version string `xml:"version,attr"`
+
+	Server struct {
+		Host string `xml:"host"`
+		Port int    `xml:"port"`
+
+		TLS struct {
+			Enabled bool   `xml:"enabled,attr"`
+			Cert    string `xml:",chardata"`
+		} `xml:"tls"`
+	} `xml:"server"`
+
+	Features []string `xml:"features>feature"`
+}
+
+func createTempXMLFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.xml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write xml file: %v", err)
+	}
+
+	return path
+}
+
+const serviceXML = `<?xml version="1.0" encoding="UTF-8"?>
+<config version="2">
+  <name>billing</name>
+  <server>
+    <host>0.0.0.0</host>
+    <port>8080</port>
+    <tls enabled="true">/etc/tls/cert.pem</tls>
+  </server>
+  <features>
+    <feature>invoices</feature>
+    <feature>refunds</feature>
+  </features>
+</config>
+`
+
+func TestLoader(t *testing.T) {
+	file := createTempXMLFile(t, serviceXML)
+
+	loader, err := xml.NewLoader[ServiceConfig]([]string{file}, xml.WithStrict())
+	if err != nil {
+		t.Fatalf("failed to create xml loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" || cfg.Version != "2" || cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != 8080 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if !cfg.Server.TLS.Enabled || cfg.Server.TLS.Cert != "/etc/tls/cert.pem" {
+		t.Errorf("expected nested element to be decoded, got %+v", cfg.Server.TLS)
+	}
+	if len(cfg.Features) != 2 || cfg.Features[1] != "refunds" {
+		t.Errorf("expected features to be decoded, got %v", cfg.Features)
+	}
+}
+
+func TestLoaderMergeFiles(t *testing.T) {
+	base := createTempXMLFile(t, serviceXML)
+	local := createTempXMLFile(t, `<config>
+  <server><port>9090</port></server>
+  <features><feature>debug</feature></features>
+</config>`)
+
+	if _, err := xml.NewLoader[ServiceConfig]([]string{base, local}); err == nil {
+		t.Fatal("expected multiple files without WithMergeFiles to be rejected")
+	}
+
+	loader, err := xml.NewLoader[ServiceConfig]([]string{base, local}, xml.WithMergeFiles())
+	if err != nil {
+		t.Fatalf("failed to create xml loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" || cfg.Server.Host != "0.0.0.0" || !cfg.Server.TLS.Enabled {
+		t.Errorf("expected values absent from later files to be kept, got %+v", cfg)
+	}
+	if cfg.Server.Port != 9090 {
+		t.Errorf("expected later file to override port, got %d", cfg.Server.Port)
+	}
+	if len(cfg.Features) != 1 || cfg.Features[0] != "debug" {
+		t.Errorf("expected slices to be replaced, got %v", cfg.Features)
+	}
+}
+
+func TestLoaderMergeFilesZeroOverride(t *testing.T) {
+	type Config struct {
+		Debug bool     `xml:"debug"`
+		Port  int      `xml:"port"`
+		Hosts []string `xml:"hosts>host"`
+	}
+
+	base := createTempXMLFile(t, `<config><debug>true</debug><port>1</port><hosts><host>a</host></hosts></config>`)
+	override := createTempXMLFile(t, `<config><debug>false</debug><port>0</port></config>`)
+
+	loader, err := xml.NewLoader[Config]([]string{base, override}, xml.WithMergeFiles())
+	if err != nil {
+		t.Fatalf("failed to create xml loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Debug || cfg.Port != 0 {
+		t.Errorf("expected false/zero values from the later file to win, got %+v", cfg)
+	}
+	if len(cfg.Hosts) != 1 || cfg.Hosts[0] != "a" {
+		t.Errorf("expected slices absent from the later file to be kept, got %v", cfg.Hosts)
+	}
+}
+
+func TestLoaderMissingFiles(t *testing.T) {
+	if _, err := xml.NewLoader[ServiceConfig](nil); !errors.Is(err, xml.ErrFilesNotSpecified) {
+		t.Errorf("expected ErrFilesNotSpecified, got %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.xml")
+
+	loader, err := xml.NewLoader[ServiceConfig]([]string{missing})
+	if err != nil {
+		t.Fatalf("failed to create xml loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, xml.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	file := createTempXMLFile(t, `<config><name>present</name></config>`)
+
+	loader, err = xml.NewLoader[ServiceConfig]([]string{file, missing}, xml.WithMergeFiles(), xml.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create xml loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "present" {
+		t.Errorf("expected Name present, got %q", cfg.Name)
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		opts          []xml.Option
+		line          int
+		column        int
+		errorContains string
+	}{
+		{
+			name:          "Syntax error",
+			content:       "<config>\n  <name>billing</name>\n  <server><port>8080</server>\n</config>",
+			line:          3,
+			errorContains: "element <port> closed by </server>",
+		},
+		{
+			name:          "Type error",
+			content:       "<config>\n  <name>billing</name>\n  <server>\n    <port>notanumber</port>\n  </server>\n</config>",
+			line:          4,
+			errorContains: `parsing "notanumber"`,
+		},
+		{
+			name:          "Empty file",
+			content:       "",
+			line:          1,
+			errorContains: "file is empty",
+		},
+		{
+			name:          "Unknown element",
+			content:       "<config>\n  <name>billing</name>\n  <server>\n    <prot>8080</prot>\n  </server>\n</config>",
+			opts:          []xml.Option{xml.WithStrict()},
+			line:          4,
+			column:        5,
+			errorContains: "unknown element <prot> in <server>",
+		},
+		{
+			name:          "Unknown attribute",
+			content:       "<config>\n  <server>\n    <tls enable=\"true\"/>\n  </server>\n</config>",
+			opts:          []xml.Option{xml.WithStrict()},
+			line:          3,
+			column:        5,
+			errorContains: `unknown attribute "enable" on <tls>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := createTempXMLFile(t, tc.content)
+
+			loader, err := xml.NewLoader[ServiceConfig]([]string{file}, tc.opts...)
+			if err != nil {
+				t.Fatalf("failed to create xml loader: %v", err)
+			}
+
+			_, err = loader.Load()
+
+			var srcErr *goconfig.SourceError
+			if !errors.As(err, &srcErr) {
+				t.Fatalf("expected *goconfig.SourceError, got %T: %v", err, err)
+			}
+
+			if srcErr.File != file {
+				t.Errorf("expected file %s, got %s", file, srcErr.File)
+			}
+
+			if srcErr.Line != tc.line || srcErr.Column != tc.column {
+				t.Errorf("expected %d:%d, got %d:%d: %v", tc.line, tc.column, srcErr.Line, srcErr.Column, err)
+			}
+
+			if !strings.Contains(err.Error(), tc.errorContains) {
+				t.Errorf("expected error to contain %q, got %v", tc.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestLoaderStrictAcceptsAnyContent(t *testing.T) {
+	type Config struct {
+		Name  string `xml:"name"`
+		Extra []struct {
+			Raw string `xml:",innerxml"`
+		} `xml:",any"`
+	}
+
+	file := createTempXMLFile(t, `<config><name>billing</name><plugin id="1"><opt/></plugin></config>`)
+
+	loader, err := xml.NewLoader[Config]([]string{file}, xml.WithStrict())
+	if err != nil {
+		t.Fatalf("failed to create xml loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Errorf("expected ,any field to accept unknown elements, got %v", err)
+	}
+}
+
+func TestLoaderLoadContext(t *testing.T) {
+	file := createTempXMLFile(t, `<config><name>billing</name></config>`)
+
+	loader, err := xml.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create xml loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewParallelLoader[ServiceConfig](loader).LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := loader.LoadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package yaml
+
+// Options defines a set of functional options for the YAML loader
+type Options struct {
+	SkipMissingFiles bool
+	MergeFiles       bool
+	KnownFields      bool
+}
+
+// Option defines a functional option for the YAML loader
+type Option func(*Options) error
+
+// WithSkipMissingFiles configures the loader to skip missing YAML files
+func WithSkipMissingFiles() Option {
+	return func(opts *Options) error {
+		opts.SkipMissingFiles = true
+		return nil
+	}
+}
+
+// WithMergeFiles allows several files to be passed to NewLoader. They are decoded in order
+// into the same value, so later files override the keys they set, nested mappings are merged
+// key by key and sequences are replaced as a whole.
+func WithMergeFiles() Option {
+	return func(opts *Options) error {
+		opts.MergeFiles = true
+		return nil
+	}
+}
+
+// WithKnownFields makes Load fail when a file contains a key that matches no field of T,
+// catching typos such as prot: for port:
+func WithKnownFields() Option {
+	return func(opts *Options) error {
+		opts.KnownFields = true
+		return nil
+	}
+}
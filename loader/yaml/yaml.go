@@ -0,0 +1,132 @@
+// Package yaml provides a configuration loader that decodes YAML files into struct fields
+// using gopkg.in/yaml.v3.
+//
+// Fields are bound with the usual `yaml:"key"` tags and nested structs map to nested
+// mappings. Files are decoded straight from disk with a streaming decoder, so the raw file
+// is never held in memory as a whole; memory use is dominated by the decoded node tree and
+// the resulting value. Syntax and type errors that carry a line number fail with a
+// *goconfig.SourceError that shows the lines around them. yaml.v3 always rejects a key set
+// twice in the same mapping, so no option is needed to catch duplicates.
+package yaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	// ErrFilesNotSpecified indicates that the NewLoader function was called with an empty Files array.
+	ErrFilesNotSpecified = errors.New("yaml files not specified")
+
+	// ErrSourceNotFound indicates that the specified source (file, etc.) could not be found.
+	// It is goconfig.ErrSourceNotFound.
+	ErrSourceNotFound = goconfig.ErrSourceNotFound
+)
+
+// linePattern extracts the line number from yaml.v3 error messages
+var linePattern = regexp.MustCompile(`line (\d+)`)
+
+// Loader implements configuration loading from YAML files
+type Loader[T any] struct {
+	Files   []string
+	Options Options
+}
+
+// NewLoader creates a new YAML config loader. Passing more than one file requires
+// WithMergeFiles, which decodes them in order so that later files override earlier ones.
+func NewLoader[T any](files []string, opts ...Option) (*Loader[T], error) {
+	if len(files) == 0 {
+		return nil, ErrFilesNotSpecified
+	}
+
+	loader := &Loader[T]{
+		Files: files,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&loader.Options); err != nil {
+			return nil, fmt.Errorf("error creating loader: invalid option: %w", err)
+		}
+	}
+
+	if len(files) > 1 && !loader.Options.MergeFiles {
+		return nil, errors.New("error creating loader: multiple yaml files require WithMergeFiles")
+	}
+
+	return loader, nil
+}
+
+// Load decodes the configured YAML files into a new T. Empty files leave T unchanged.
+// It is equivalent to LoadContext(context.Background()).
+func (l *Loader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext is like Load but checks ctx before each file, returning ctx.Err() once it is done
+func (l *Loader[T]) LoadContext(ctx context.Context) (*T, error) {
+	var cfg T
+	for _, filename := range l.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := l.decodeFile(filename, &cfg); err != nil {
+			if l.Options.SkipMissingFiles && errors.Is(err, ErrSourceNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+	}
+
+	return &cfg, nil
+}
+
+// decodeFile decodes a single YAML file into cfg
+func (l *Loader[T]) decodeFile(filename string, cfg *T) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = ErrSourceNotFound
+		}
+
+		return fmt.Errorf("error loading yaml file %s: %w", filename, err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(l.Options.KnownFields)
+
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("error loading yaml file: %w", sourceError(filename, err))
+	}
+
+	return nil
+}
+
+// sourceError wraps a decode error in a *goconfig.SourceError when it names a line.
+// The file is only read again in that case, to show the lines around the error.
+func sourceError(filename string, err error) error {
+	match := linePattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	line, _ := strconv.Atoi(match[1])
+
+	data, readErr := os.ReadFile(filename)
+	if readErr != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return goconfig.NewSourceError(filename, data, line, err)
+}
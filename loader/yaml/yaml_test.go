@@ -0,0 +1,294 @@
+package yaml_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikita-shtimenko/goconfig"
+	"github.com/nikita-shtimenko/goconfig/loader/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type ServiceConfig struct {
+	Name string `yaml:"name"`
+
+	Server struct {
+		Host    string        `yaml:"host"`
+		Port    int           `yaml:"port"`
+		Timeout time.Duration `yaml:"timeout"`
+	} `yaml:"server"`
+
+	Features []string          `yaml:"features"`
+	Labels   map[string]string `yaml:"labels"`
+}
+
+func createTempYAMLFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write yaml file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoader(t *testing.T) {
+	file := createTempYAMLFile(t, `name: billing
+server:
+  host: 0.0.0.0
+  port: 8080
+  timeout: 5s
+features: [invoices, refunds]
+labels:
+  team: payments
+`)
+
+	loader, err := yaml.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create yaml loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+	if cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != 8080 || cfg.Server.Timeout != 5*time.Second {
+		t.Errorf("unexpected server section: %+v", cfg.Server)
+	}
+	if len(cfg.Features) != 2 || cfg.Features[1] != "refunds" {
+		t.Errorf("unexpected features: %v", cfg.Features)
+	}
+	if cfg.Labels["team"] != "payments" {
+		t.Errorf("unexpected labels: %v", cfg.Labels)
+	}
+}
+
+func TestLoaderMergeFiles(t *testing.T) {
+	base := createTempYAMLFile(t, `name: base
+server:
+  host: 0.0.0.0
+  port: 8080
+features: [invoices, refunds]
+labels:
+  team: payments
+`)
+	local := createTempYAMLFile(t, `server:
+  port: 9090
+features: [debug]
+labels:
+  env: local
+`)
+
+	loader, err := yaml.NewLoader[ServiceConfig]([]string{base, local}, yaml.WithMergeFiles())
+	if err != nil {
+		t.Fatalf("failed to create yaml loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "base" || cfg.Server.Host != "0.0.0.0" {
+		t.Errorf("expected keys absent from later files to be kept, got %+v", cfg)
+	}
+	if cfg.Server.Port != 9090 {
+		t.Errorf("expected later file to override port, got %d", cfg.Server.Port)
+	}
+	if len(cfg.Features) != 1 || cfg.Features[0] != "debug" {
+		t.Errorf("expected sequences to be replaced, got %v", cfg.Features)
+	}
+	if cfg.Labels["team"] != "payments" || cfg.Labels["env"] != "local" {
+		t.Errorf("expected mappings to be merged, got %v", cfg.Labels)
+	}
+}
+
+func TestNewLoaderErrors(t *testing.T) {
+	if _, err := yaml.NewLoader[ServiceConfig](nil); !errors.Is(err, yaml.ErrFilesNotSpecified) {
+		t.Errorf("expected ErrFilesNotSpecified, got %v", err)
+	}
+
+	if _, err := yaml.NewLoader[ServiceConfig]([]string{"a.yaml", "b.yaml"}); err == nil {
+		t.Error("expected error for multiple files without WithMergeFiles")
+	}
+}
+
+func TestLoaderMissingFiles(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+
+	loader, err := yaml.NewLoader[ServiceConfig]([]string{missing})
+	if err != nil {
+		t.Fatalf("failed to create yaml loader: %v", err)
+	}
+
+	if _, err := loader.Load(); !errors.Is(err, yaml.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	file := createTempYAMLFile(t, "name: present\n")
+
+	loader, err = yaml.NewLoader[ServiceConfig]([]string{file, missing}, yaml.WithMergeFiles(), yaml.WithSkipMissingFiles())
+	if err != nil {
+		t.Fatalf("failed to create yaml loader: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "present" {
+		t.Errorf("expected Name present, got %q", cfg.Name)
+	}
+}
+
+func TestLoaderEmptyFile(t *testing.T) {
+	loader, err := yaml.NewLoader[ServiceConfig]([]string{createTempYAMLFile(t, "")})
+	if err != nil {
+		t.Fatalf("failed to create yaml loader: %v", err)
+	}
+
+	if _, err := loader.Load(); err != nil {
+		t.Errorf("expected empty file to load, got %v", err)
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		opts          []yaml.Option
+		line          int
+		errorContains string
+	}{
+		{
+			name:          "Syntax error",
+			content:       "name: billing\nserver:\n\tport: 8080\n",
+			line:          3,
+			errorContains: "found character that cannot start any token",
+		},
+		{
+			name:          "Type error",
+			content:       "name: billing\nserver:\n  port: notanumber\n",
+			line:          3,
+			errorContains: "cannot unmarshal",
+		},
+		{
+			name:          "Unknown field",
+			content:       "name: billing\nserver:\n  prot: 8080\n",
+			opts:          []yaml.Option{yaml.WithKnownFields()},
+			line:          3,
+			errorContains: "field prot not found",
+		},
+		{
+			name:          "Duplicate key",
+			content:       "name: billing\nserver:\n  port: 8080\n  port: 9090\n",
+			line:          4,
+			errorContains: `mapping key "port" already defined at line 3`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader, err := yaml.NewLoader[ServiceConfig]([]string{createTempYAMLFile(t, tc.content)}, tc.opts...)
+			if err != nil {
+				t.Fatalf("failed to create yaml loader: %v", err)
+			}
+
+			_, err = loader.Load()
+
+			var srcErr *goconfig.SourceError
+			if !errors.As(err, &srcErr) {
+				t.Fatalf("expected *goconfig.SourceError, got %T: %v", err, err)
+			}
+
+			if srcErr.Line != tc.line {
+				t.Errorf("expected line %d, got %d", tc.line, srcErr.Line)
+			}
+
+			if !strings.Contains(err.Error(), tc.errorContains) {
+				t.Errorf("expected error to contain %q, got %v", tc.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestLoaderLoadContext(t *testing.T) {
+	file := createTempYAMLFile(t, "name: billing\n")
+
+	loader, err := yaml.NewLoader[ServiceConfig]([]string{file})
+	if err != nil {
+		t.Fatalf("failed to create yaml loader: %v", err)
+	}
+
+	cfg, err := goconfig.NewParallelLoader[ServiceConfig](loader).LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Name != "billing" {
+		t.Errorf("expected Name billing, got %q", cfg.Name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := loader.LoadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+// BenchmarkLoad compares the loader, which decodes straight from the opened file, with
+// reading the whole file into memory and unmarshaling it
+func BenchmarkLoad(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString("name: billing\nserver:\n  host: 0.0.0.0\n  port: 8080\nfeatures:\n")
+	for i := range 10000 {
+		fmt.Fprintf(&sb, "  - rule-%d\n", i)
+	}
+
+	path := filepath.Join(b.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o600); err != nil {
+		b.Fatalf("failed to write yaml file: %v", err)
+	}
+
+	b.Run("Decoder", func(b *testing.B) {
+		loader, err := yaml.NewLoader[ServiceConfig]([]string{path})
+		if err != nil {
+			b.Fatalf("failed to create yaml loader: %v", err)
+		}
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := loader.Load(); err != nil {
+				b.Fatalf("unexpected error loading config: %v", err)
+			}
+		}
+	})
+
+	b.Run("ReadFile", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				b.Fatalf("failed to read yaml file: %v", err)
+			}
+
+			var cfg ServiceConfig
+			if err := yamlv3.Unmarshal(data, &cfg); err != nil {
+				b.Fatalf("unexpected error unmarshaling config: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package goconfig
+
+import "reflect"
+
+// Merge returns a new configuration combining base and override.
+// Non-zero fields of override win over base; nested structs are merged recursively, while
+// slices, maps and other non-struct values from override replace the base value entirely
+// when they are non-nil/non-zero. Structs with unexported fields (such as time.Time) are
+// treated as single values. Neither argument is modified; if both are nil, Merge returns nil.
+func Merge[T any](base, override *T) *T {
+	switch {
+	case base == nil && override == nil:
+		return nil
+	case base == nil:
+		merged := *override
+		return &merged
+	case override == nil:
+		merged := *base
+		return &merged
+	}
+
+	merged := *base
+	mergeValue(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(override).Elem(), "", nil)
+
+	return &merged
+}
+
+// mergeValue merges src into dst, which must be settable. If record is not nil, it is called
+// with the dotted path of every value taken from src, e.g. "Database.Host".
+func mergeValue(dst, src reflect.Value, path string, record func(path string)) {
+	switch {
+	case src.IsZero():
+		return
+	case dst.Kind() == reflect.Struct && isMergeableStruct(dst.Type()):
+		for i := range dst.NumField() {
+			mergeValue(dst.Field(i), src.Field(i), fieldPath(path, dst.Type().Field(i).Name), record)
+		}
+	case dst.Kind() == reflect.Pointer && isMergeableStruct(dst.Type().Elem()):
+		merged := reflect.New(dst.Type().Elem())
+		if !dst.IsNil() {
+			merged.Elem().Set(dst.Elem())
+		}
+		mergeValue(merged.Elem(), src.Elem(), path, record)
+		dst.Set(merged)
+	default:
+		dst.Set(src)
+		if record != nil {
+			record(path)
+		}
+	}
+}
+
+// fieldPath appends name to the dotted field path
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// isMergeableStruct reports whether t is a struct whose fields are all exported and can
+// therefore be merged field by field
+func isMergeableStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.NumField() == 0 {
+		return false
+	}
+
+	for i := range t.NumField() {
+		if !t.Field(i).IsExported() {
+			return false
+		}
+	}
+
+	return true
+}
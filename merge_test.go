@@ -0,0 +1,118 @@
+package goconfig_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type mergeConfig struct {
+	AppName string
+	Port    int
+	Debug   bool
+	Hosts   []string
+	Labels  map[string]string
+	Started time.Time
+
+	Database struct {
+		Host string
+		Port int
+	}
+
+	Cache *struct {
+		TTL  time.Duration
+		Size int
+	}
+}
+
+func TestMergeNestedOverrides(t *testing.T) {
+	base := &mergeConfig{AppName: "base", Port: 8080}
+	base.Database.Host = "db.base"
+	base.Database.Port = 5432
+
+	override := &mergeConfig{}
+	override.Database.Host = "db.override"
+
+	merged := goconfig.Merge(base, override)
+
+	if merged.Database.Host != "db.override" {
+		t.Errorf("Database.Host: expected %q, got %q", "db.override", merged.Database.Host)
+	}
+	if merged.Database.Port != 5432 {
+		t.Errorf("Database.Port: expected base %d to be preserved, got %d", 5432, merged.Database.Port)
+	}
+	if base.Database.Host != "db.base" {
+		t.Errorf("expected base to be left untouched, got %q", base.Database.Host)
+	}
+}
+
+func TestMergePreservesZeroValues(t *testing.T) {
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	base := &mergeConfig{AppName: "base", Port: 8080, Debug: true, Started: started}
+	override := &mergeConfig{AppName: "override"}
+
+	merged := goconfig.Merge(base, override)
+
+	want := &mergeConfig{AppName: "override", Port: 8080, Debug: true, Started: started}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("expected %+v, got %+v", want, merged)
+	}
+}
+
+func TestMergeReplacesSlicesAndMaps(t *testing.T) {
+	base := &mergeConfig{
+		Hosts:  []string{"a", "b", "c"},
+		Labels: map[string]string{"team": "core", "tier": "1"},
+	}
+	override := &mergeConfig{
+		Hosts:  []string{"z"},
+		Labels: map[string]string{"team": "platform"},
+	}
+
+	merged := goconfig.Merge(base, override)
+
+	if !reflect.DeepEqual(merged.Hosts, []string{"z"}) {
+		t.Errorf("Hosts: expected override slice, got %v", merged.Hosts)
+	}
+	if !reflect.DeepEqual(merged.Labels, map[string]string{"team": "platform"}) {
+		t.Errorf("Labels: expected override map, got %v", merged.Labels)
+	}
+}
+
+func TestMergeNestedPointers(t *testing.T) {
+	base := &mergeConfig{}
+	base.Cache = &struct {
+		TTL  time.Duration
+		Size int
+	}{TTL: time.Minute, Size: 100}
+
+	override := &mergeConfig{}
+	override.Cache = &struct {
+		TTL  time.Duration
+		Size int
+	}{Size: 500}
+
+	merged := goconfig.Merge(base, override)
+
+	if merged.Cache.TTL != time.Minute || merged.Cache.Size != 500 {
+		t.Errorf("Cache: expected TTL=1m Size=500, got %+v", *merged.Cache)
+	}
+	if base.Cache.Size != 100 {
+		t.Errorf("expected base pointer target to be left untouched, got %d", base.Cache.Size)
+	}
+}
+
+func TestMergeNil(t *testing.T) {
+	override := &mergeConfig{AppName: "override"}
+
+	merged := goconfig.Merge(nil, override)
+	if merged == override || merged.AppName != "override" {
+		t.Errorf("expected a copy of override, got %+v", merged)
+	}
+
+	if goconfig.Merge[mergeConfig](nil, nil) != nil {
+		t.Error("expected nil when both inputs are nil")
+	}
+}
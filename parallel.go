@@ -0,0 +1,105 @@
+package goconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ParallelLoader runs several loaders concurrently and merges their results. It suits
+// independent sources that populate disjoint parts of one configuration.
+type ParallelLoader[T any] struct {
+	loaders []ConfigLoaderContext[T]
+}
+
+// NewParallelLoader creates a loader that runs every loader in its own goroutine.
+// Non-zero fields of all results are combined with Merge in the order the loaders are
+// given, so later loaders win where their fields overlap.
+func NewParallelLoader[T any](loaders ...ConfigLoaderContext[T]) *ParallelLoader[T] {
+	return &ParallelLoader[T]{loaders: loaders}
+}
+
+// Load loads the configuration from all loaders concurrently
+func (l *ParallelLoader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext loads the configuration from all loaders concurrently. The first failure
+// cancels the context passed to the remaining loaders and is returned.
+func (l *ParallelLoader[T]) LoadContext(ctx context.Context) (*T, error) {
+	cfg, _, err := l.load(ctx, false)
+	return cfg, err
+}
+
+// LoadWithProvenance loads the configuration like LoadContext and also reports which loader
+// supplied each value: the result maps the dotted path of every field taken from a loader,
+// e.g. "Database.Host", to that loader's index. Fields no loader set are absent. Slices, maps
+// and structs with unexported fields are attributed as a whole.
+func (l *ParallelLoader[T]) LoadWithProvenance(ctx context.Context) (*T, map[string]int, error) {
+	return l.load(ctx, true)
+}
+
+// load runs all loaders and merges their results, recording provenance if requested
+func (l *ParallelLoader[T]) load(ctx context.Context, trackProvenance bool) (*T, map[string]int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		results  = make([]*T, len(l.loaders))
+		firstErr error
+	)
+
+	for i, loader := range l.loaders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cfg, err := loader.LoadContext(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("parallel loader %d failed: %w", i, err)
+					cancel()
+				}
+				return
+			}
+
+			results[i] = cfg
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+
+	var provenance map[string]int
+	if trackProvenance {
+		provenance = make(map[string]int)
+	}
+
+	merged := new(T)
+	for i, cfg := range results {
+		if cfg == nil {
+			continue
+		}
+
+		var record func(string)
+		if trackProvenance {
+			record = func(path string) {
+				provenance[path] = i
+			}
+		}
+
+		mergeValue(reflect.ValueOf(merged).Elem(), reflect.ValueOf(cfg).Elem(), "", record)
+	}
+
+	return merged, provenance, nil
+}
@@ -0,0 +1,113 @@
+package goconfig_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+func TestParallelLoaderMergesResults(t *testing.T) {
+	name := &fakeLoader{
+		delay: 10 * time.Millisecond,
+		load: func(int) (*sampleConfig, error) {
+			return &sampleConfig{AppName: "parallel"}, nil
+		},
+	}
+	port := &fakeLoader{
+		delay: 10 * time.Millisecond,
+		load: func(int) (*sampleConfig, error) {
+			return &sampleConfig{Port: 8080}, nil
+		},
+	}
+
+	cfg, err := goconfig.NewParallelLoader[sampleConfig](name, port).Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.AppName != "parallel" || cfg.Port != 8080 {
+		t.Errorf("expected both fields to be merged, got %+v", cfg)
+	}
+}
+
+func TestParallelLoaderPropagatesFailure(t *testing.T) {
+	errSource := errors.New("source unavailable")
+
+	failing := &fakeLoader{
+		load: func(int) (*sampleConfig, error) {
+			return nil, errSource
+		},
+	}
+	slow := &fakeLoader{
+		delay: time.Minute,
+		load: func(int) (*sampleConfig, error) {
+			return &sampleConfig{Port: 8080}, nil
+		},
+	}
+
+	start := time.Now()
+	_, err := goconfig.NewParallelLoader[sampleConfig](failing, slow).LoadContext(context.Background())
+
+	if !errors.Is(err, errSource) {
+		t.Fatalf("expected source error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the slow loader to be cancelled, took %v", elapsed)
+	}
+}
+
+type layeredConfig struct {
+	Port     int
+	LogLevel string
+
+	Database struct {
+		Host string
+		Name string
+	}
+}
+
+// layerLoader returns a fixed configuration
+type layerLoader struct {
+	cfg *layeredConfig
+}
+
+func (l layerLoader) Load() (*layeredConfig, error) {
+	return l.cfg, nil
+}
+
+func (l layerLoader) LoadContext(context.Context) (*layeredConfig, error) {
+	return l.cfg, nil
+}
+
+func TestParallelLoaderLoadWithProvenance(t *testing.T) {
+	dotenv := &layeredConfig{Port: 8080, LogLevel: "info"}
+	dotenv.Database.Name = "app"
+
+	consul := &layeredConfig{LogLevel: "debug"}
+	consul.Database.Host = "db.consul"
+
+	cfg, provenance, err := goconfig.NewParallelLoader[layeredConfig](layerLoader{dotenv}, layerLoader{consul}).
+		LoadWithProvenance(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Port != 8080 || cfg.LogLevel != "debug" || cfg.Database.Host != "db.consul" || cfg.Database.Name != "app" {
+		t.Errorf("unexpected merged config %+v", cfg)
+	}
+
+	want := map[string]int{
+		"Port":          0,
+		"LogLevel":      1,
+		"Database.Host": 1,
+		"Database.Name": 0,
+	}
+	if !reflect.DeepEqual(provenance, want) {
+		t.Errorf("provenance mismatch\nexpected: %v\ngot:      %v", want, provenance)
+	}
+}
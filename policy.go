@@ -0,0 +1,86 @@
+package goconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nikita-shtimenko/goconfig/internal/fields"
+)
+
+// Policy describes team conventions that the env tags of a configuration struct must follow.
+// Zero-valued rules are not checked.
+type Policy struct {
+	// KeyPrefix requires every environment key to start with the given prefix, e.g. "BILLING_"
+	KeyPrefix string
+
+	// SecretKeywords lists case-insensitive substrings (e.g. "PASSWORD", "TOKEN") that mark
+	// a key as sensitive; such fields must be tagged secret:"true"
+	SecretKeywords []string
+
+	// RequireDescriptions requires every field to carry a non-empty doc tag
+	RequireDescriptions bool
+}
+
+// Policy rules reported in Violation.Rule
+const (
+	RuleKeyPrefix   = "key-prefix"
+	RuleSecretTag   = "secret-tag"
+	RuleDescription = "description"
+)
+
+// Violation describes a field that breaks a policy rule
+type Violation struct {
+	// Field is the dotted Go path of the field, e.g. "Database.Password"
+	Field string
+
+	// Key is the fully prefixed environment key of the field
+	Key string
+
+	// Rule is the violated rule, one of the Rule constants
+	Rule string
+
+	// Message explains the violation
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s (%s): %s", v.Field, v.Key, v.Message)
+}
+
+// CheckPolicy checks the env-tagged fields of T against policy, recursing into nested structs
+// and applying their envPrefix tags. It returns the violations in field order, or nil if T complies.
+// It is meant to be run from tests to enforce conventions across a team.
+func CheckPolicy[T any](policy Policy) []Violation {
+	var violations []Violation
+	(fields.Walker{}).Walk(reflect.TypeFor[T](), func(f fields.Field) {
+		violate := func(rule, format string, args ...any) {
+			violations = append(violations, Violation{
+				Field:   f.Name,
+				Key:     f.Key,
+				Rule:    rule,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		if policy.KeyPrefix != "" && !strings.HasPrefix(f.Key, policy.KeyPrefix) {
+			violate(RuleKeyPrefix, "key must start with %q", policy.KeyPrefix)
+		}
+
+		if !f.Secret() {
+			upper := strings.ToUpper(f.Key)
+			for _, keyword := range policy.SecretKeywords {
+				if strings.Contains(upper, strings.ToUpper(keyword)) {
+					violate(RuleSecretTag, "key looks sensitive (%s) but is not tagged secret:\"true\"", keyword)
+					break
+				}
+			}
+		}
+
+		if policy.RequireDescriptions && strings.TrimSpace(f.Struct.Tag.Get("doc")) == "" {
+			violate(RuleDescription, "field has no doc tag")
+		}
+	})
+
+	return violations
+}
@@ -0,0 +1,50 @@
+package goconfig_test
+
+import (
+	"reflect"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var teamPolicy = goconfig.Policy{
+	KeyPrefix:           "BILLING_",
+	SecretKeywords:      []string{"PASSWORD", "TOKEN"},
+	RequireDescriptions: true,
+}
+
+type compliantConfig struct {
+	Port int `env:"PORT" doc:"HTTP listen port"`
+
+	Database struct {
+		Password string `env:"PASSWORD" secret:"true" doc:"database password"`
+	} `envPrefix:"DB_"`
+}
+
+type nonCompliantConfig struct {
+	Port     int    `env:"PORT"`
+	APIToken string `env:"BILLING_API_TOKEN" doc:"upstream API token"`
+}
+
+func TestCheckPolicyCompliant(t *testing.T) {
+	type prefixed struct {
+		Config compliantConfig `envPrefix:"BILLING_"`
+	}
+
+	if violations := goconfig.CheckPolicy[prefixed](teamPolicy); violations != nil {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
+
+func TestCheckPolicyNonCompliant(t *testing.T) {
+	want := []goconfig.Violation{
+		{Field: "Port", Key: "PORT", Rule: goconfig.RuleKeyPrefix, Message: `key must start with "BILLING_"`},
+		{Field: "Port", Key: "PORT", Rule: goconfig.RuleDescription, Message: "field has no doc tag"},
+		{Field: "APIToken", Key: "BILLING_API_TOKEN", Rule: goconfig.RuleSecretTag, Message: `key looks sensitive (TOKEN) but is not tagged secret:"true"`},
+	}
+
+	got := goconfig.CheckPolicy[nonCompliantConfig](teamPolicy)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations mismatch\nexpected: %v\ngot:      %v", want, got)
+	}
+}
@@ -0,0 +1,104 @@
+package goconfig
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// PollOptions defines a set of functional options for WatchPoll
+type PollOptions struct {
+	// MaxBackoff caps the wait between polls after consecutive failures
+	MaxBackoff time.Duration
+
+	// OnError is called for every failed poll
+	OnError func(error)
+}
+
+// PollOption defines a functional option for WatchPoll
+type PollOption func(*PollOptions)
+
+// WithPollMaxBackoff caps the wait between polls after consecutive failures, 16 intervals by default
+func WithPollMaxBackoff(d time.Duration) PollOption {
+	return func(opts *PollOptions) {
+		opts.MaxBackoff = d
+	}
+}
+
+// WithPollErrorHandler registers a callback for failed polls
+func WithPollErrorHandler(fn func(error)) PollOption {
+	return func(opts *PollOptions) {
+		opts.OnError = fn
+	}
+}
+
+// WatchPoll watches a source that cannot push changes, such as an HTTP config server,
+// by calling loader every interval. The first successful load sets the baseline; after
+// that, a configuration is sent only when it differs from the last good one according to
+// reflect.DeepEqual, whatever tags its fields use. A failed poll keeps the last good configuration,
+// is passed to the handler set with WithPollErrorHandler and doubles the wait before the
+// next poll up to WithPollMaxBackoff. The channel is closed when ctx is done.
+func WatchPoll[T any](ctx context.Context, loader ConfigLoaderContext[T], interval time.Duration, opts ...PollOption) <-chan *T {
+	return watchPoll(ctx, loader, interval, nil, opts...)
+}
+
+// watchPoll implements WatchPoll. A non-nil baseline is used as the last good configuration,
+// so the first poll happens after interval and is compared against it.
+func watchPoll[T any](ctx context.Context, loader ConfigLoaderContext[T], interval time.Duration, baseline *T, opts ...PollOption) <-chan *T {
+	options := PollOptions{MaxBackoff: 16 * interval}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	updates := make(chan *T)
+
+	go func() {
+		defer close(updates)
+
+		last := baseline
+		wait := time.Duration(0)
+		if last != nil {
+			wait = interval
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+
+			cfg, err := loader.LoadContext(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				if options.OnError != nil {
+					options.OnError(err)
+				}
+
+				wait = min(max(2*wait, interval), max(options.MaxBackoff, interval))
+				continue
+			}
+
+			wait = interval
+			if last == nil {
+				last = cfg
+				continue
+			}
+
+			if reflect.DeepEqual(last, cfg) {
+				continue
+			}
+
+			last = cfg
+			select {
+			case updates <- cfg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates
+}
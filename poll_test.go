@@ -0,0 +1,172 @@
+package goconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type remoteConfig struct {
+	Port int `env:"PORT" json:"port"`
+}
+
+// httpLoader fetches a JSON configuration from a config server
+type httpLoader struct {
+	url string
+}
+
+func (l httpLoader) Load() (*remoteConfig, error) {
+	return l.LoadContext(context.Background())
+}
+
+func (l httpLoader) LoadContext(ctx context.Context) (*remoteConfig, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var cfg remoteConfig
+	if err := json.NewDecoder(resp.Body).Decode(&cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func TestWatchPoll(t *testing.T) {
+	var port, requests atomic.Int64
+	port.Store(8080)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprintf(w, `{"port": %d}`, port.Load())
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := goconfig.WatchPoll[remoteConfig](ctx, httpLoader{url: server.URL}, 10*time.Millisecond)
+
+	// wait for the baseline and an unchanged poll before changing the response
+	for requests.Load() < 3 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	port.Store(9090)
+
+	select {
+	case cfg := <-updates:
+		if cfg.Port != 9090 {
+			t.Errorf("expected port 9090, got %d", cfg.Port)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a change event")
+	}
+
+	select {
+	case cfg := <-updates:
+		t.Fatalf("expected exactly one change event, got another: %+v", cfg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// countingLoader returns a different port on every load, bound only with a json tag as
+// remote loaders do
+type countingLoader struct {
+	calls *atomic.Int64
+}
+
+type jsonOnlyConfig struct {
+	Port int `json:"port"`
+}
+
+func (l countingLoader) Load() (*jsonOnlyConfig, error) {
+	return l.LoadContext(context.Background())
+}
+
+func (l countingLoader) LoadContext(context.Context) (*jsonOnlyConfig, error) {
+	return &jsonOnlyConfig{Port: int(l.calls.Add(1))}, nil
+}
+
+func TestWatchPollDetectsChangesWithoutEnvTags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := goconfig.WatchPoll[jsonOnlyConfig](ctx, countingLoader{calls: new(atomic.Int64)}, 5*time.Millisecond)
+
+	select {
+	case cfg := <-updates:
+		if cfg.Port < 2 {
+			t.Errorf("expected a config loaded after the baseline, got port %d", cfg.Port)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a change event")
+	}
+}
+
+func TestWatchPollKeepsServingOnError(t *testing.T) {
+	var failing atomic.Bool
+	var port atomic.Int64
+	port.Store(8080)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"port": %d}`, port.Load())
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 100)
+	updates := goconfig.WatchPoll[remoteConfig](ctx, httpLoader{url: server.URL}, 10*time.Millisecond,
+		goconfig.WithPollMaxBackoff(20*time.Millisecond),
+		goconfig.WithPollErrorHandler(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}),
+	)
+
+	time.Sleep(30 * time.Millisecond)
+	failing.Store(true)
+
+	select {
+	case <-errs:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a poll error")
+	}
+
+	port.Store(9090)
+	failing.Store(false)
+
+	select {
+	case cfg := <-updates:
+		if cfg.Port != 9090 {
+			t.Errorf("expected port 9090, got %d", cfg.Port)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a change event after recovery")
+	}
+}
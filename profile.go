@@ -0,0 +1,127 @@
+package goconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrUnknownProfile indicates that the active profile is not defined under profiles.
+	ErrUnknownProfile = errors.New("unknown profile")
+
+	// ErrNoActiveProfile indicates that neither the active key nor the profile environment
+	// variable selects a profile.
+	ErrNoActiveProfile = errors.New("no active profile")
+)
+
+// ActiveProfileLoader loads one profile from a file that defines several, such as
+//
+//	active: staging
+//	profiles:
+//	  staging:
+//	    port: 8080
+//	  production:
+//	    port: 443
+type ActiveProfileLoader[T any] struct {
+	path          string
+	format        Format
+	profileEnvVar string
+}
+
+// NewActiveProfileLoader creates a loader that binds the profile selected by the active key
+// of the file at path, decoded as format. If profileEnvVar is not empty and that environment
+// variable is set, its value selects the profile instead. The profile is decoded into T with
+// the struct tags of format, as by Unmarshal.
+func NewActiveProfileLoader[T any](path string, format Format, profileEnvVar string) *ActiveProfileLoader[T] {
+	return &ActiveProfileLoader[T]{path: path, format: format, profileEnvVar: profileEnvVar}
+}
+
+// Load loads the active profile
+// It is equivalent to LoadContext(context.Background()).
+func (l *ActiveProfileLoader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext loads the active profile, or returns ctx.Err() if ctx is done before the file is read
+func (l *ActiveProfileLoader[T]) LoadContext(ctx context.Context) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	cfg, err := l.load()
+	if err != nil {
+		return nil, fmt.Errorf("error loading profile from %s: %w", l.path, err)
+	}
+
+	return cfg, nil
+}
+
+// load reads the file, selects the active profile and decodes it into a new T
+func (l *ActiveProfileLoader[T]) load() (*T, error) {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrSourceNotFound
+		}
+
+		return nil, err
+	}
+
+	doc, err := decodeDocument(data, l.format)
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return nil, errors.New("top-level value is not a mapping")
+	}
+
+	profiles, ok := root["profiles"].(map[string]any)
+	if !ok {
+		return nil, errors.New("profiles is missing or not a mapping")
+	}
+
+	active, _ := root["active"].(string)
+	if l.profileEnvVar != "" {
+		if name := os.Getenv(l.profileEnvVar); name != "" {
+			active = name
+		}
+	}
+
+	if active == "" {
+		return nil, ErrNoActiveProfile
+	}
+
+	profile, ok := profiles[active]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		return nil, fmt.Errorf("%w %q, available: %s", ErrUnknownProfile, active, strings.Join(names, ", "))
+	}
+
+	if profile == nil {
+		profile = map[string]any{}
+	}
+
+	// Re-encode the profile alone so that T is decoded with the tags of the file's format
+	out, err := encodeDocument(profile, l.format)
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: %w", active, err)
+	}
+
+	var cfg T
+	if err := Unmarshal(out, l.format, &cfg); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", active, err)
+	}
+
+	return &cfg, nil
+}
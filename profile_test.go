@@ -0,0 +1,114 @@
+package goconfig_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type profileConfig struct {
+	Host string `json:"host" yaml:"host" toml:"host"`
+	Port int    `json:"port" yaml:"port" toml:"port"`
+}
+
+func writeProfileFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write profile file: %v", err)
+	}
+
+	return path
+}
+
+const yamlProfiles = `active: staging
+profiles:
+  staging:
+    host: staging.internal
+    port: 8080
+  production:
+    host: prod.internal
+    port: 443
+`
+
+func TestActiveProfileLoader(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		format  goconfig.Format
+	}{
+		{name: "YAML", file: "profiles.yaml", content: yamlProfiles, format: goconfig.FormatYAML},
+		{
+			name:    "JSON",
+			file:    "profiles.json",
+			content: `{"active": "staging", "profiles": {"staging": {"host": "staging.internal", "port": 8080}, "production": {"host": "prod.internal", "port": 443}}}`,
+			format:  goconfig.FormatJSON,
+		},
+		{
+			name:    "TOML",
+			file:    "profiles.toml",
+			content: "active = \"staging\"\n\n[profiles.staging]\nhost = \"staging.internal\"\nport = 8080\n\n[profiles.production]\nhost = \"prod.internal\"\nport = 443\n",
+			format:  goconfig.FormatTOML,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeProfileFile(t, tc.file, tc.content)
+
+			cfg, err := goconfig.NewActiveProfileLoader[profileConfig](path, tc.format, "").Load()
+			if err != nil {
+				t.Fatalf("unexpected error loading profile: %v", err)
+			}
+
+			if want := (profileConfig{Host: "staging.internal", Port: 8080}); *cfg != want {
+				t.Errorf("expected %+v, got %+v", want, *cfg)
+			}
+		})
+	}
+}
+
+func TestActiveProfileLoaderEnvOverride(t *testing.T) {
+	path := writeProfileFile(t, "profiles.yaml", yamlProfiles)
+	t.Setenv("APP_PROFILE", "production")
+
+	cfg, err := goconfig.NewActiveProfileLoader[profileConfig](path, goconfig.FormatYAML, "APP_PROFILE").Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading profile: %v", err)
+	}
+
+	if cfg.Host != "prod.internal" || cfg.Port != 443 {
+		t.Errorf("expected the production profile, got %+v", cfg)
+	}
+}
+
+func TestActiveProfileLoaderErrors(t *testing.T) {
+	t.Setenv("APP_PROFILE", "qa")
+
+	path := writeProfileFile(t, "profiles.yaml", yamlProfiles)
+
+	_, err := goconfig.NewActiveProfileLoader[profileConfig](path, goconfig.FormatYAML, "APP_PROFILE").Load()
+	if !errors.Is(err, goconfig.ErrUnknownProfile) || !strings.Contains(err.Error(), `"qa", available: production, staging`) {
+		t.Errorf("expected ErrUnknownProfile listing the profiles, got %v", err)
+	}
+
+	noActive := writeProfileFile(t, "profiles.yaml", "profiles:\n  staging:\n    port: 8080\n")
+	if _, err := goconfig.NewActiveProfileLoader[profileConfig](noActive, goconfig.FormatYAML, "").Load(); !errors.Is(err, goconfig.ErrNoActiveProfile) {
+		t.Errorf("expected ErrNoActiveProfile, got %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	if _, err := goconfig.NewActiveProfileLoader[profileConfig](missing, goconfig.FormatYAML, "").Load(); !errors.Is(err, goconfig.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	if _, err := goconfig.NewActiveProfileLoader[profileConfig](path, goconfig.Format("ini"), "").Load(); !errors.Is(err, goconfig.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
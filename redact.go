@@ -0,0 +1,113 @@
+package goconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Redact returns a deep copy of cfg in which every exported field tagged secret:"true" is
+// masked, whatever tag it is bound with: strings, including those behind pointers, are
+// replaced by "******" and values of other types are reset to zero. Nested structs are
+// followed through pointers, slices, arrays and maps. cfg itself is not modified.
+// Redact returns nil for nil.
+func Redact[T any](cfg *T) *T {
+	redacted := Clone(cfg)
+	if redacted == nil {
+		return nil
+	}
+
+	maskSecrets(reflect.ValueOf(redacted).Elem(), make(map[visitKey]bool))
+
+	return redacted
+}
+
+// maskSecrets masks the secret fields of every struct reachable from v. visited guards
+// against cycles of pointers.
+func maskSecrets(v reflect.Value, visited map[visitKey]bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+
+		key := visitKey{typ: v.Type(), ptr: v.Pointer()}
+		if visited[key] {
+			return
+		}
+
+		visited[key] = true
+		maskSecrets(v.Elem(), visited)
+	case reflect.Interface:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Pointer {
+			maskSecrets(v.Elem(), visited)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			if field.Tag.Get("secret") == "true" {
+				maskValue(v.Field(i))
+				continue
+			}
+
+			maskSecrets(v.Field(i), visited)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			maskSecrets(v.Index(i), visited)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// Map values are not addressable, so mask a copy and store it back
+			value := reflect.New(iter.Value().Type()).Elem()
+			value.Set(iter.Value())
+			maskSecrets(value, visited)
+			v.SetMapIndex(iter.Key(), value)
+		}
+	}
+}
+
+// maskValue masks the secret value v in place
+func maskValue(v reflect.Value) {
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(secretMask)
+	case v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.String:
+		masked := reflect.New(v.Type().Elem())
+		masked.Elem().SetString(secretMask)
+		v.Set(masked)
+	default:
+		v.SetZero()
+	}
+}
+
+// RedactedString wraps a configuration so that printing it with %v or %s, or marshaling it
+// to JSON, never reveals the values of fields tagged secret:"true". See Redact.
+type RedactedString[T any] struct {
+	cfg *T
+}
+
+// NewRedactedString wraps cfg for safe logging. The wrapper reads cfg each time it is printed.
+func NewRedactedString[T any](cfg *T) RedactedString[T] {
+	return RedactedString[T]{cfg: cfg}
+}
+
+// String formats the redacted configuration like %+v
+func (r RedactedString[T]) String() string {
+	redacted := Redact(r.cfg)
+	if redacted == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("%+v", *redacted)
+}
+
+// MarshalJSON marshals the redacted configuration, honoring the json tags of T
+func (r RedactedString[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redact(r.cfg))
+}
@@ -0,0 +1,153 @@
+package goconfig_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type redactConfig struct {
+	Host     string  `env:"HOST" json:"host"`
+	Password string  `env:"PASSWORD" secret:"true" json:"password"`
+	Token    *string `env:"TOKEN" secret:"true" json:"token"`
+	PIN      int     `env:"PIN" secret:"true" json:"pin"`
+	Database struct {
+		User     string `env:"USER" json:"user"`
+		Password string `env:"PASSWORD" secret:"true" json:"password"`
+	} `envPrefix:"DB_" json:"database"`
+}
+
+func newRedactConfig() *redactConfig {
+	token := "tok-123"
+	cfg := &redactConfig{Host: "api.internal", Password: "hunter2", Token: &token, PIN: 1234}
+	cfg.Database.User = "app"
+	cfg.Database.Password = "db-s3cr3t"
+	return cfg
+}
+
+func TestRedact(t *testing.T) {
+	cfg := newRedactConfig()
+
+	redacted := goconfig.Redact(cfg)
+
+	if redacted.Host != "api.internal" || redacted.Database.User != "app" {
+		t.Errorf("expected normal fields to be kept, got %+v", redacted)
+	}
+	if redacted.Password != "******" || redacted.Database.Password != "******" {
+		t.Errorf("expected secret strings to be masked, got %q and %q", redacted.Password, redacted.Database.Password)
+	}
+	if redacted.Token == nil || *redacted.Token != "******" {
+		t.Errorf("expected secret string pointer to be masked, got %v", redacted.Token)
+	}
+	if redacted.PIN != 0 {
+		t.Errorf("expected secret int to be zeroed, got %d", redacted.PIN)
+	}
+
+	if cfg.Password != "hunter2" || *cfg.Token != "tok-123" {
+		t.Error("expected Redact not to modify the original config")
+	}
+
+	if goconfig.Redact[redactConfig](nil) != nil {
+		t.Error("expected Redact(nil) to return nil")
+	}
+}
+
+func TestRedactedStringString(t *testing.T) {
+	cfg := newRedactConfig()
+
+	for _, out := range []string{
+		goconfig.NewRedactedString(cfg).String(),
+		fmt.Sprintf("%v", goconfig.NewRedactedString(cfg)),
+	} {
+		for _, secret := range []string{"hunter2", "tok-123", "1234", "db-s3cr3t"} {
+			if strings.Contains(out, secret) {
+				t.Errorf("expected %q to be masked in %s", secret, out)
+			}
+		}
+
+		for _, visible := range []string{"Host:api.internal", "User:app", "Password:******"} {
+			if !strings.Contains(out, visible) {
+				t.Errorf("expected %q in %s", visible, out)
+			}
+		}
+	}
+
+	if out := goconfig.NewRedactedString[redactConfig](nil).String(); out != "<nil>" {
+		t.Errorf("expected <nil> for a nil config, got %q", out)
+	}
+}
+
+func TestRedactedStringMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(map[string]any{"config": goconfig.NewRedactedString(newRedactConfig())})
+	if err != nil {
+		t.Fatalf("unexpected error marshaling config: %v", err)
+	}
+
+	var got struct {
+		Config struct {
+			Host     string `json:"host"`
+			Password string `json:"password"`
+			Token    string `json:"token"`
+			PIN      int    `json:"pin"`
+			Database struct {
+				User     string `json:"user"`
+				Password string `json:"password"`
+			} `json:"database"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unexpected error unmarshaling %s: %v", data, err)
+	}
+
+	c := got.Config
+	if c.Host != "api.internal" || c.Database.User != "app" {
+		t.Errorf("expected normal fields in JSON, got %s", data)
+	}
+	if c.Password != "******" || c.Token != "******" || c.PIN != 0 || c.Database.Password != "******" {
+		t.Errorf("expected secrets to be masked in JSON, got %s", data)
+	}
+}
+
+func TestRedactWithoutEnvTags(t *testing.T) {
+	type Credentials struct {
+		Password string `json:"password" secret:"true"`
+	}
+
+	type Config struct {
+		User     string                 `json:"user"`
+		Password string                 `json:"password" secret:"true"`
+		Replicas []Credentials          `json:"replicas"`
+		Backends map[string]Credentials `json:"backends"`
+	}
+
+	cfg := &Config{
+		User:     "u",
+		Password: "hunter2",
+		Replicas: []Credentials{{Password: "replica-pass"}},
+		Backends: map[string]Credentials{"primary": {Password: "backend-pass"}},
+	}
+
+	out := goconfig.NewRedactedString(cfg).String()
+
+	data, err := json.Marshal(goconfig.NewRedactedString(cfg))
+	if err != nil {
+		t.Fatalf("unexpected error marshaling config: %v", err)
+	}
+
+	for _, secret := range []string{"hunter2", "replica-pass", "backend-pass"} {
+		if strings.Contains(out, secret) || strings.Contains(string(data), secret) {
+			t.Errorf("expected %q to be masked, got %s and %s", secret, out, data)
+		}
+	}
+
+	if !strings.Contains(out, "User:u") || !strings.Contains(string(data), `"password":"******"`) {
+		t.Errorf("unexpected redacted output %s and %s", out, data)
+	}
+
+	if cfg.Password != "hunter2" || cfg.Replicas[0].Password != "replica-pass" || cfg.Backends["primary"].Password != "backend-pass" {
+		t.Error("expected Redact not to modify the original config")
+	}
+}
@@ -0,0 +1,63 @@
+package goconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownLoaderScheme indicates that no loader factory is registered for the scheme of a URI
+// and the requested configuration type.
+var ErrUnknownLoaderScheme = errors.New("unknown loader scheme")
+
+// LoaderFactory creates a loader for T from a URI such as file://./app.env
+type LoaderFactory[T any] func(uri string) (ConfigLoader[T], error)
+
+// registryKey identifies a factory by scheme and configuration type
+type registryKey struct {
+	scheme string
+	typ    reflect.Type
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[registryKey]any{}
+)
+
+// RegisterLoader registers factory as the way to create loaders for T from URIs with the given
+// scheme, e.g. "consul". Factories are registered per configuration type, since a generic
+// loader has to be instantiated for each type. Registering a scheme again for the same type
+// replaces the previous factory.
+func RegisterLoader[T any](scheme string, factory LoaderFactory[T]) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	factories[registryKey{scheme: scheme, typ: reflect.TypeFor[T]()}] = factory
+}
+
+// NewLoaderFromURI creates a loader for T with the factory registered for the scheme of uri,
+// so that the configuration source itself can be chosen by configuration, e.g. a CONFIG_URI
+// variable. The whole uri is passed to the factory.
+func NewLoaderFromURI[T any](uri string) (ConfigLoader[T], error) {
+	scheme, _, ok := strings.Cut(uri, "://")
+	if !ok || scheme == "" {
+		return nil, fmt.Errorf("error creating loader from URI %q: missing scheme", uri)
+	}
+
+	factoriesMu.RLock()
+	factory, ok := factories[registryKey{scheme: scheme, typ: reflect.TypeFor[T]()}]
+	factoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("error creating loader from URI %q: %w %q for %s", uri, ErrUnknownLoaderScheme, scheme, reflect.TypeFor[T]())
+	}
+
+	loader, err := factory.(LoaderFactory[T])(uri)
+	if err != nil {
+		return nil, fmt.Errorf("error creating loader from URI %q: %w", uri, err)
+	}
+
+	return loader, nil
+}
@@ -0,0 +1,92 @@
+package goconfig_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type registryConfig struct {
+	Port int
+}
+
+func TestNewLoaderFromURI(t *testing.T) {
+	goconfig.RegisterLoader("fake", func(uri string) (goconfig.ConfigLoader[registryConfig], error) {
+		port, err := strconv.Atoi(strings.TrimPrefix(uri, "fake://"))
+		if err != nil {
+			return nil, err
+		}
+
+		return goconfig.NewStaticLoader(&registryConfig{Port: port}), nil
+	})
+
+	loader, err := goconfig.NewLoaderFromURI[registryConfig]("fake://8080")
+	if err != nil {
+		t.Fatalf("failed to create loader from URI: %v", err)
+	}
+
+	cfg, err := goconfig.NewConfig(loader)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Port != 8080 {
+		t.Errorf("expected port 8080, got %d", cfg.Port)
+	}
+
+	if _, err := goconfig.NewLoaderFromURI[registryConfig]("fake://notaport"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected wrapped factory error, got %v", err)
+	}
+}
+
+func TestNewLoaderFromURIErrors(t *testing.T) {
+	goconfig.RegisterLoader("typed", func(string) (goconfig.ConfigLoader[registryConfig], error) {
+		return goconfig.NewStaticLoader(&registryConfig{}), nil
+	})
+
+	tests := []struct {
+		name    string
+		load    func() error
+		wantErr error
+	}{
+		{
+			name: "Unknown scheme",
+			load: func() error {
+				_, err := goconfig.NewLoaderFromURI[registryConfig]("consul://services/billing")
+				return err
+			},
+			wantErr: goconfig.ErrUnknownLoaderScheme,
+		},
+		{
+			name: "Scheme registered for another type",
+			load: func() error {
+				_, err := goconfig.NewLoaderFromURI[remoteConfig]("typed://anything")
+				return err
+			},
+			wantErr: goconfig.ErrUnknownLoaderScheme,
+		},
+		{
+			name: "Missing scheme",
+			load: func() error {
+				_, err := goconfig.NewLoaderFromURI[registryConfig]("./app.env")
+				return err
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.load()
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
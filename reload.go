@@ -0,0 +1,181 @@
+package goconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrWatchNotSupported indicates that a loader cannot push changes and no polling fallback was configured.
+var ErrWatchNotSupported = errors.New("loader does not support watching")
+
+// Watcher is implemented by loaders that push configuration changes, such as the etcd loader
+type Watcher[T any] interface {
+	Watch(ctx context.Context) <-chan *T
+}
+
+// Reloader provides the current configuration and notifies about reloads
+type Reloader[T any] interface {
+	Current() *T
+	Subscribe() <-chan *T
+}
+
+// ReloadOptions defines a set of functional options for the reload manager
+type ReloadOptions struct {
+	// PollInterval enables polling through WatchPoll for loaders that do not implement Watcher
+	PollInterval time.Duration
+
+	// PollOptions are passed to WatchPoll
+	PollOptions []PollOption
+
+	// OnError is called for every reloaded configuration that is rejected
+	OnError func(error)
+}
+
+// ReloadOption defines a functional option for the reload manager
+type ReloadOption func(*ReloadOptions)
+
+// WithPollFallback polls loaders that do not implement Watcher every interval using WatchPoll
+func WithPollFallback(interval time.Duration, opts ...PollOption) ReloadOption {
+	return func(o *ReloadOptions) {
+		o.PollInterval = interval
+		o.PollOptions = opts
+	}
+}
+
+// WithReloadErrorHandler registers a callback for rejected reloads, e.g. ones that would change
+// a field tagged immutable:"true"
+func WithReloadErrorHandler(fn func(error)) ReloadOption {
+	return func(o *ReloadOptions) {
+		o.OnError = fn
+	}
+}
+
+// ReloadManager keeps the latest configuration of a loader and fans reloads out to subscribers.
+// It implements Reloader. A reload that would change a field tagged immutable:"true", such as
+// a cluster ID, is rejected: the current configuration is kept, nothing is published and the
+// error, wrapping ErrImmutableFieldChanged, goes to the handler set with WithReloadErrorHandler.
+type ReloadManager[T any] struct {
+	loader  ConfigLoaderContext[T]
+	options ReloadOptions
+	current atomic.Pointer[T]
+
+	mu          sync.Mutex
+	started     bool
+	closed      bool
+	subscribers []chan *T
+}
+
+// NewReloadManager creates a reload manager for loader. Call Start to load the initial
+// configuration and begin watching.
+func NewReloadManager[T any](loader ConfigLoaderContext[T], opts ...ReloadOption) *ReloadManager[T] {
+	manager := &ReloadManager[T]{loader: loader}
+
+	for _, opt := range opts {
+		opt(&manager.options)
+	}
+
+	return manager
+}
+
+// Start loads the initial configuration and watches for changes until ctx is done, using
+// the loader's Watch method if it implements Watcher and polling if WithPollFallback is set.
+// Polling compares against the initial configuration, so a change made right after it was
+// loaded is still published.
+// Otherwise it returns ErrWatchNotSupported. Subscriber channels are closed when watching ends.
+func (m *ReloadManager[T]) Start(ctx context.Context) error {
+	m.mu.Lock()
+	if m.started {
+		m.mu.Unlock()
+		return errors.New("reload manager already started")
+	}
+	m.started = true
+	m.mu.Unlock()
+
+	watcher, canWatch := m.loader.(Watcher[T])
+	if !canWatch && m.options.PollInterval <= 0 {
+		return ErrWatchNotSupported
+	}
+
+	cfg, err := m.loader.LoadContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error loading initial config: %w", err)
+	}
+	m.current.Store(cfg)
+
+	var updates <-chan *T
+	if canWatch {
+		updates = watcher.Watch(ctx)
+	} else {
+		updates = watchPoll(ctx, m.loader, m.options.PollInterval, cfg, m.options.PollOptions...)
+	}
+
+	go m.run(updates)
+
+	return nil
+}
+
+// Current returns the latest configuration, or nil before Start has loaded it.
+// It never blocks and is safe to call while a reload is in progress.
+func (m *ReloadManager[T]) Current() *T {
+	return m.current.Load()
+}
+
+// Subscribe returns a channel that receives every reloaded configuration. A subscriber that
+// falls behind only receives the latest one. The channel is closed when watching ends.
+func (m *ReloadManager[T]) Subscribe() <-chan *T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch := make(chan *T, 1)
+	if m.closed {
+		close(ch)
+		return ch
+	}
+
+	m.subscribers = append(m.subscribers, ch)
+	return ch
+}
+
+// run applies updates until the watch channel is closed
+func (m *ReloadManager[T]) run(updates <-chan *T) {
+	for cfg := range updates {
+		if err := checkImmutable(m.current.Load(), cfg); err != nil {
+			if m.options.OnError != nil {
+				m.options.OnError(fmt.Errorf("reload rejected: %w", err))
+			}
+
+			continue
+		}
+
+		m.current.Store(cfg)
+		m.publish(cfg)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	for _, ch := range m.subscribers {
+		close(ch)
+	}
+	m.subscribers = nil
+}
+
+// publish sends cfg to every subscriber, replacing a value the subscriber has not received yet
+func (m *ReloadManager[T]) publish(cfg *T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ch := range m.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- cfg
+	}
+}
@@ -0,0 +1,263 @@
+package goconfig_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+// watchingLoader is a loader that pushes the configurations sent on changes
+type watchingLoader struct {
+	initial *remoteConfig
+	changes chan *remoteConfig
+}
+
+func (l *watchingLoader) Load() (*remoteConfig, error) {
+	return l.LoadContext(context.Background())
+}
+
+func (l *watchingLoader) LoadContext(context.Context) (*remoteConfig, error) {
+	return l.initial, nil
+}
+
+func (l *watchingLoader) Watch(ctx context.Context) <-chan *remoteConfig {
+	return l.changes
+}
+
+func TestReloadManager(t *testing.T) {
+	loader := &watchingLoader{
+		initial: &remoteConfig{Port: 8080},
+		changes: make(chan *remoteConfig),
+	}
+
+	manager := goconfig.NewReloadManager[remoteConfig](loader)
+	if err := manager.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error starting reload manager: %v", err)
+	}
+
+	if got := manager.Current().Port; got != 8080 {
+		t.Fatalf("expected initial port 8080, got %d", got)
+	}
+
+	updates := manager.Subscribe()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					if cfg := manager.Current(); cfg == nil || cfg.Port < 8080 {
+						t.Errorf("unexpected current config %+v", cfg)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for port := 8081; port <= 8083; port++ {
+		loader.changes <- &remoteConfig{Port: port}
+
+		select {
+		case cfg := <-updates:
+			if cfg.Port != port {
+				t.Errorf("expected update with port %d, got %d", port, cfg.Port)
+			}
+
+			if current := manager.Current(); current != cfg {
+				t.Errorf("expected Current to return the received config, got %+v", current)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for update %d", port)
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+
+	close(loader.changes)
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Error("expected subscriber channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for subscriber channel to close")
+	}
+}
+
+func TestReloadManagerWatchNotSupported(t *testing.T) {
+	manager := goconfig.NewReloadManager[remoteConfig](httpLoader{url: "http://127.0.0.1:0"})
+
+	if err := manager.Start(context.Background()); !errors.Is(err, goconfig.ErrWatchNotSupported) {
+		t.Fatalf("expected ErrWatchNotSupported, got %v", err)
+	}
+}
+
+func TestReloadManagerPollFallback(t *testing.T) {
+	manager := goconfig.NewReloadManager[jsonOnlyConfig](countingLoader{calls: new(atomic.Int64)},
+		goconfig.WithPollFallback(5*time.Millisecond),
+	)
+
+	updates := manager.Subscribe()
+	if err := manager.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error starting reload manager: %v", err)
+	}
+
+	select {
+	case cfg := <-updates:
+		if cfg.Port < 2 {
+			t.Errorf("expected a polled config after the initial load, got port %d", cfg.Port)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a polled update")
+	}
+}
+
+// sequenceLoader returns the next configuration on every load and then keeps returning the last one
+type sequenceLoader struct {
+	mu      sync.Mutex
+	configs []*jsonOnlyConfig
+}
+
+func (l *sequenceLoader) Load() (*jsonOnlyConfig, error) {
+	return l.LoadContext(context.Background())
+}
+
+func (l *sequenceLoader) LoadContext(context.Context) (*jsonOnlyConfig, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cfg := l.configs[0]
+	if len(l.configs) > 1 {
+		l.configs = l.configs[1:]
+	}
+
+	return cfg, nil
+}
+
+func TestReloadManagerPollPublishesChangeAfterInitialLoad(t *testing.T) {
+	// The source changes right after the initial load, before the first poll
+	loader := &sequenceLoader{configs: []*jsonOnlyConfig{{Port: 8080}, {Port: 8081}}}
+
+	manager := goconfig.NewReloadManager[jsonOnlyConfig](loader, goconfig.WithPollFallback(5*time.Millisecond))
+
+	updates := manager.Subscribe()
+	if err := manager.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error starting reload manager: %v", err)
+	}
+
+	if got := manager.Current().Port; got != 8080 {
+		t.Fatalf("expected initial port 8080, got %d", got)
+	}
+
+	select {
+	case cfg := <-updates:
+		if cfg.Port != 8081 {
+			t.Errorf("expected the changed config with port 8081, got %d", cfg.Port)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the change made after the initial load")
+	}
+}
+
+type clusterConfig struct {
+	ClusterID string `immutable:"true"`
+	Port      int
+	Database  *struct {
+		Region string `immutable:"true"`
+		Host   string
+	}
+}
+
+// clusterLoader pushes the cluster configurations sent on changes
+type clusterLoader struct {
+	initial *clusterConfig
+	changes chan *clusterConfig
+}
+
+func (l *clusterLoader) Load() (*clusterConfig, error) {
+	return l.LoadContext(context.Background())
+}
+
+func (l *clusterLoader) LoadContext(context.Context) (*clusterConfig, error) {
+	return l.initial, nil
+}
+
+func (l *clusterLoader) Watch(ctx context.Context) <-chan *clusterConfig {
+	return l.changes
+}
+
+func TestReloadManagerImmutableFields(t *testing.T) {
+	newConfig := func(clusterID string, port int, region, host string) *clusterConfig {
+		cfg := &clusterConfig{ClusterID: clusterID, Port: port}
+		cfg.Database = &struct {
+			Region string `immutable:"true"`
+			Host   string
+		}{Region: region, Host: host}
+		return cfg
+	}
+
+	loader := &clusterLoader{
+		initial: newConfig("eu-1", 8080, "eu-west", "db-a"),
+		changes: make(chan *clusterConfig),
+	}
+
+	rejected := make(chan error, 2)
+	manager := goconfig.NewReloadManager[clusterConfig](loader, goconfig.WithReloadErrorHandler(func(err error) {
+		rejected <- err
+	}))
+	if err := manager.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error starting reload manager: %v", err)
+	}
+
+	updates := manager.Subscribe()
+
+	for _, change := range []*clusterConfig{
+		newConfig("eu-2", 9090, "eu-west", "db-a"),
+		newConfig("eu-1", 9090, "us-east", "db-a"),
+	} {
+		loader.changes <- change
+
+		select {
+		case err := <-rejected:
+			if !errors.Is(err, goconfig.ErrImmutableFieldChanged) {
+				t.Errorf("expected ErrImmutableFieldChanged, got %v", err)
+			}
+		case cfg := <-updates:
+			t.Fatalf("expected reload to be rejected, got %+v", cfg)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the rejected reload")
+		}
+
+		if got := manager.Current(); got.Port != 8080 || got.ClusterID != "eu-1" {
+			t.Errorf("expected the old config to be kept, got %+v", got)
+		}
+	}
+
+	loader.changes <- newConfig("eu-1", 9090, "eu-west", "db-b")
+
+	select {
+	case cfg := <-updates:
+		if cfg.Port != 9090 || cfg.Database.Host != "db-b" {
+			t.Errorf("expected mutable changes to apply, got %+v", cfg)
+		}
+	case err := <-rejected:
+		t.Fatalf("unexpected rejection: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for update")
+	}
+
+	close(loader.changes)
+}
@@ -0,0 +1,99 @@
+package goconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RetryOptions defines a set of functional options for the retry loader
+type RetryOptions struct {
+	// NonRetryable lists errors that stop retrying immediately when matched with errors.Is
+	NonRetryable []error
+}
+
+// RetryOption defines a functional option for the retry loader
+type RetryOption func(*RetryOptions)
+
+// WithNonRetryable marks errors that should not be retried
+func WithNonRetryable(errs ...error) RetryOption {
+	return func(opts *RetryOptions) {
+		opts.NonRetryable = append(opts.NonRetryable, errs...)
+	}
+}
+
+// RetryLoader wraps a loader and retries failed loads with exponential backoff
+type RetryLoader[T any] struct {
+	inner    ConfigLoaderContext[T]
+	attempts int
+	backoff  time.Duration
+	options  RetryOptions
+}
+
+// NewRetryLoader creates a loader that calls inner up to attempts times, waiting backoff
+// before the second attempt and doubling the wait after each further failure.
+func NewRetryLoader[T any](inner ConfigLoaderContext[T], attempts int, backoff time.Duration, opts ...RetryOption) *RetryLoader[T] {
+	loader := &RetryLoader[T]{
+		inner:    inner,
+		attempts: max(attempts, 1),
+		backoff:  backoff,
+	}
+
+	for _, opt := range opts {
+		opt(&loader.options)
+	}
+
+	return loader
+}
+
+// Load loads the configuration, retrying on failure
+func (l *RetryLoader[T]) Load() (*T, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext loads the configuration, retrying on failure until the attempts are
+// exhausted, a non-retryable error occurs, or ctx is done
+func (l *RetryLoader[T]) LoadContext(ctx context.Context) (*T, error) {
+	wait := l.backoff
+
+	var err error
+	for attempt := 1; attempt <= l.attempts; attempt++ {
+		var cfg *T
+		cfg, err = l.inner.LoadContext(ctx)
+		if err == nil {
+			return cfg, nil
+		}
+
+		if l.nonRetryable(err) {
+			return nil, fmt.Errorf("config load failed after %d attempt(s): %w", attempt, err)
+		}
+
+		if attempt == l.attempts {
+			break
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("config load failed after %d attempt(s): %w", attempt, errors.Join(err, ctx.Err()))
+		}
+
+		wait *= 2
+	}
+
+	return nil, fmt.Errorf("config load failed after %d attempt(s): %w", l.attempts, err)
+}
+
+// nonRetryable reports whether err matches one of the configured non-retryable errors
+func (l *RetryLoader[T]) nonRetryable(err error) bool {
+	for _, target := range l.options.NonRetryable {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package goconfig_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var (
+	errTransient = errors.New("transient failure")
+	errFatal     = errors.New("fatal failure")
+)
+
+func TestRetryLoaderSucceedsAfterFailures(t *testing.T) {
+	inner := &fakeLoader{
+		load: func(call int) (*sampleConfig, error) {
+			if call <= 2 {
+				return nil, errTransient
+			}
+			return &sampleConfig{AppName: "retried"}, nil
+		},
+	}
+
+	loader := goconfig.NewRetryLoader[sampleConfig](inner, 5, time.Millisecond)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AppName != "retried" {
+		t.Errorf("AppName: expected %q, got %q", "retried", cfg.AppName)
+	}
+	if calls := inner.calls.Load(); calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryLoaderExhaustsAttempts(t *testing.T) {
+	inner := &fakeLoader{
+		load: func(int) (*sampleConfig, error) { return nil, errTransient },
+	}
+
+	loader := goconfig.NewRetryLoader[sampleConfig](inner, 3, time.Millisecond)
+
+	_, err := loader.Load()
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("expected transient error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "3 attempt(s)") {
+		t.Errorf("expected error to mention the attempt count, got %v", err)
+	}
+}
+
+func TestRetryLoaderStopsOnNonRetryable(t *testing.T) {
+	inner := &fakeLoader{
+		load: func(int) (*sampleConfig, error) { return nil, errFatal },
+	}
+
+	loader := goconfig.NewRetryLoader[sampleConfig](inner, 5, time.Millisecond, goconfig.WithNonRetryable(errFatal))
+
+	if _, err := loader.Load(); !errors.Is(err, errFatal) {
+		t.Fatalf("expected fatal error, got %v", err)
+	}
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Errorf("expected a single call, got %d", calls)
+	}
+}
+
+func TestRetryLoaderHonorsContext(t *testing.T) {
+	inner := &fakeLoader{
+		load: func(int) (*sampleConfig, error) { return nil, errTransient },
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	loader := goconfig.NewRetryLoader[sampleConfig](inner, 10, time.Hour)
+
+	if _, err := loader.LoadContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
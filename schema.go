@@ -0,0 +1,230 @@
+package goconfig
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// jsonSchemaDraft07 is the $schema URI of the emitted schemas
+const jsonSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// GenerateJSONSchema returns a draft-07 JSON Schema describing the configuration type T.
+// Property names are taken from the json tag, then the env tag, then the field name.
+// Fields tagged required:"true" (or carrying the env required option) are listed as
+// required, and doc tags become descriptions. Nested structs become nested object schemas;
+// time.Duration, []byte and encoding.TextMarshaler types are described as strings.
+// Self-referential types are described with $ref, pointing at the root schema or at an
+// entry of its definitions.
+func GenerateJSONSchema[T any]() ([]byte, error) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("error generating JSON schema: config type is not a struct")
+	}
+
+	b := schemaBuilder{
+		root:        t,
+		inProgress:  make(map[reflect.Type]bool),
+		recursive:   make(map[reflect.Type]bool),
+		names:       make(map[reflect.Type]string),
+		definitions: make(map[string]any),
+	}
+
+	schema := b.typeSchema(t)
+	schema["$schema"] = jsonSchemaDraft07
+	schema["title"] = t.Name()
+
+	if len(b.definitions) > 0 {
+		schema["definitions"] = b.definitions
+	}
+
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error generating JSON schema: %w", err)
+	}
+
+	return out, nil
+}
+
+var (
+	durationType      = reflect.TypeFor[time.Duration]()
+	timeType          = reflect.TypeFor[time.Time]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schemaBuilder builds the schema of a type, tracking the struct types being described so
+// that self-referential types end in a $ref instead of recursing forever
+type schemaBuilder struct {
+	root        reflect.Type
+	inProgress  map[reflect.Type]bool
+	recursive   map[reflect.Type]bool
+	names       map[reflect.Type]string
+	definitions map[string]any
+}
+
+// typeSchema returns the schema of a single Go type
+func (b *schemaBuilder) typeSchema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == durationType:
+		return map[string]any{"type": "string"}
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		// encoding/json marshals []byte as a base64 string
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return b.structSchema(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema returns the object schema of a struct type. A struct type that contains
+// itself is referenced with "#" if it is the root type, and otherwise moved to the
+// definitions and referenced from there.
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]any {
+	if name, ok := b.names[t]; ok {
+		if _, defined := b.definitions[name]; defined {
+			return b.ref(t)
+		}
+	}
+
+	if b.inProgress[t] {
+		b.recursive[t] = true
+		return b.ref(t)
+	}
+
+	b.inProgress[t] = true
+	defer delete(b.inProgress, t)
+
+	properties := make(map[string]any)
+	var required []string
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, ok := schemaPropertyName(sf)
+		if !ok {
+			continue
+		}
+
+		prop := b.typeSchema(sf.Type)
+		if _, isRef := prop["$ref"]; isRef && sf.Tag.Get("doc") != "" {
+			// Keywords next to $ref are ignored in draft-07, so wrap the reference
+			prop = map[string]any{"allOf": []any{prop}}
+		}
+
+		if doc := sf.Tag.Get("doc"); doc != "" {
+			prop["description"] = doc
+		}
+
+		properties[name] = prop
+
+		if isSchemaRequired(sf) {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	if b.recursive[t] && t != b.root {
+		b.definitions[b.names[t]] = schema
+		return b.ref(t)
+	}
+
+	return schema
+}
+
+// ref returns a $ref to the schema of the struct type t, naming its definition on first use
+func (b *schemaBuilder) ref(t reflect.Type) map[string]any {
+	if t == b.root {
+		return map[string]any{"$ref": "#"}
+	}
+
+	name, ok := b.names[t]
+	if !ok {
+		name = t.Name()
+		for other, taken := range b.names {
+			if taken == name && other != t {
+				name = t.String()
+			}
+		}
+
+		b.names[t] = name
+	}
+
+	return map[string]any{"$ref": "#/definitions/" + name}
+}
+
+// schemaPropertyName returns the property name of a field, reporting false for fields
+// excluded with a "-" tag
+func schemaPropertyName(sf reflect.StructField) (string, bool) {
+	for _, tag := range []string{"json", "env"} {
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "-" {
+			return "", false
+		}
+
+		if name != "" {
+			return name, true
+		}
+	}
+
+	return sf.Name, true
+}
+
+// isSchemaRequired reports whether a field is tagged required:"true" or carries the env required option
+func isSchemaRequired(sf reflect.StructField) bool {
+	if sf.Tag.Get("required") == "true" {
+		return true
+	}
+
+	_, opts, _ := strings.Cut(sf.Tag.Get("env"), ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "required" {
+			return true
+		}
+	}
+
+	return false
+}
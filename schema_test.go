@@ -0,0 +1,121 @@
+package goconfig_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type schemaConfig struct {
+	AppName string            `env:"APP_NAME" required:"true" doc:"application name"`
+	Port    int               `json:"port" env:"PORT" doc:"HTTP listen port"`
+	Debug   bool              `env:"DEBUG"`
+	Ratio   float64           `env:"RATIO"`
+	Timeout time.Duration     `env:"TIMEOUT"`
+	Hosts   []string          `env:"HOSTS,required"`
+	Labels  map[string]string `env:"LABELS"`
+	Ignored string            `env:"-"`
+
+	Database struct {
+		Host string `env:"HOST" required:"true"`
+		Port int    `env:"PORT"`
+	} `envPrefix:"DB_" doc:"database connection"`
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	got, err := goconfig.GenerateJSONSchema[schemaConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "schema.golden.json")
+	if *update {
+		if err := os.WriteFile(golden, append(got, '\n'), 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(got), bytes.TrimSpace(want)) {
+		t.Errorf("schema mismatch\nexpected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestGenerateJSONSchemaNotAStruct(t *testing.T) {
+	if _, err := goconfig.GenerateJSONSchema[string](); err == nil {
+		t.Error("expected error for non-struct type, got nil")
+	}
+}
+
+type schemaNode struct {
+	Name     string       `json:"name"`
+	Children []schemaNode `json:"children"`
+}
+
+type schemaTreeConfig struct {
+	Root schemaNode  `json:"root" doc:"tree root"`
+	Next *schemaNode `json:"next"`
+	Key  []byte      `json:"key"`
+}
+
+func TestGenerateJSONSchemaRecursiveTypes(t *testing.T) {
+	got, err := goconfig.GenerateJSONSchema[schemaNode]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(got, &schema); err != nil {
+		t.Fatalf("invalid schema %s: %v", got, err)
+	}
+
+	items := schema["properties"].(map[string]any)["children"].(map[string]any)["items"].(map[string]any)
+	if items["$ref"] != "#" {
+		t.Errorf("expected children to reference the root schema, got %s", got)
+	}
+
+	got, err = goconfig.GenerateJSONSchema[schemaTreeConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := json.Unmarshal(got, &schema); err != nil {
+		t.Fatalf("invalid schema %s: %v", got, err)
+	}
+
+	definition, ok := schema["definitions"].(map[string]any)["schemaNode"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a schemaNode definition, got %s", got)
+	}
+
+	items = definition["properties"].(map[string]any)["children"].(map[string]any)["items"].(map[string]any)
+	if items["$ref"] != "#/definitions/schemaNode" {
+		t.Errorf("expected children to reference the definition, got %s", got)
+	}
+
+	properties := schema["properties"].(map[string]any)
+	if next := properties["next"].(map[string]any); next["$ref"] != "#/definitions/schemaNode" {
+		t.Errorf("expected next to reference the definition, got %s", got)
+	}
+
+	root := properties["root"].(map[string]any)
+	if root["description"] != "tree root" || root["allOf"] == nil {
+		t.Errorf("expected documented reference to be wrapped in allOf, got %s", got)
+	}
+
+	if key := properties["key"].(map[string]any); key["type"] != "string" {
+		t.Errorf("expected []byte to be described as a string, got %s", got)
+	}
+}
@@ -0,0 +1,134 @@
+package goconfig
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrInvalidSortKey indicates that a sortBy tag names a missing or unsortable field.
+var ErrInvalidSortKey = errors.New("invalid sortBy key")
+
+// SortSlices stably sorts every slice-of-struct field of cfg tagged sortBy:"Field" by the
+// named field of its elements, recursing into nested structs and slice elements. Slices
+// assembled from merged or map-based sources then come out in a deterministic order.
+// Sort keys must be strings, integers or floats; elements may be structs or pointers to
+// structs, and nil elements sort first.
+func SortSlices[T any](cfg *T) error {
+	if cfg == nil {
+		return nil
+	}
+
+	return sortValue(reflect.ValueOf(cfg).Elem(), "")
+}
+
+// sortValue sorts the tagged slices reachable from v, which must be addressable
+func sortValue(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+
+		return sortValue(v.Elem(), path)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			name := sf.Name
+			if path != "" {
+				name = path + "." + sf.Name
+			}
+
+			field := v.Field(i)
+			if key, ok := sf.Tag.Lookup("sortBy"); ok {
+				if err := sortSlice(field, name, key); err != nil {
+					return err
+				}
+			}
+
+			if err := sortValue(field, name); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			if err := sortValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortSlice stably sorts the slice v by the field key of its elements
+func sortSlice(v reflect.Value, name, key string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("%w: %s: sortBy requires a slice field, got %s", ErrInvalidSortKey, name, v.Type())
+	}
+
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %s: sortBy requires a slice of structs, got %s", ErrInvalidSortKey, name, v.Type())
+	}
+
+	sf, ok := elem.FieldByName(key)
+	if !ok || !sf.IsExported() {
+		return fmt.Errorf("%w: %s: %s has no field %q", ErrInvalidSortKey, name, elem, key)
+	}
+
+	var compare func(a, b reflect.Value) int
+	switch sf.Type.Kind() {
+	case reflect.String:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.String(), b.String()) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Int(), b.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Uint(), b.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Float(), b.Float()) }
+	default:
+		return fmt.Errorf("%w: %s: field %q has unsortable type %s", ErrInvalidSortKey, name, key, sf.Type)
+	}
+
+	keyOf := func(i int) (reflect.Value, bool) {
+		item := v.Index(i)
+		if item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				return reflect.Value{}, false
+			}
+			item = item.Elem()
+		}
+
+		return item.FieldByIndex(sf.Index), true
+	}
+
+	sort.SliceStable(v.Interface(), func(i, j int) bool {
+		a, okA := keyOf(i)
+		b, okB := keyOf(j)
+		if !okA || !okB {
+			return !okA && okB
+		}
+
+		return compare(a, b) < 0
+	})
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package goconfig_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type upstream struct {
+	Name   string
+	Weight int
+}
+
+type routingConfig struct {
+	Upstreams []upstream `sortBy:"Name"`
+
+	Fallback struct {
+		Upstreams []*upstream `sortBy:"Weight"`
+	}
+}
+
+func TestSortSlicesMerged(t *testing.T) {
+	base := &routingConfig{}
+	base.Fallback.Upstreams = []*upstream{{Name: "b", Weight: 20}, {Name: "a", Weight: 10}}
+
+	override := &routingConfig{
+		Upstreams: []upstream{{Name: "gamma"}, {Name: "alpha"}, {Name: "beta"}},
+	}
+
+	merged := goconfig.Merge(base, override)
+	if err := goconfig.SortSlices(merged); err != nil {
+		t.Fatalf("unexpected error sorting slices: %v", err)
+	}
+
+	wantUpstreams := []upstream{{Name: "alpha"}, {Name: "beta"}, {Name: "gamma"}}
+	if !reflect.DeepEqual(merged.Upstreams, wantUpstreams) {
+		t.Errorf("expected upstreams %v, got %v", wantUpstreams, merged.Upstreams)
+	}
+
+	wantFallback := []*upstream{{Name: "a", Weight: 10}, {Name: "b", Weight: 20}}
+	if !reflect.DeepEqual(merged.Fallback.Upstreams, wantFallback) {
+		t.Errorf("expected fallback upstreams %v, got %v", wantFallback, merged.Fallback.Upstreams)
+	}
+}
+
+func TestSortSlicesInvalidKey(t *testing.T) {
+	type config struct {
+		Upstreams []upstream `sortBy:"Missing"`
+	}
+
+	err := goconfig.SortSlices(&config{Upstreams: []upstream{{Name: "a"}}})
+	if !errors.Is(err, goconfig.ErrInvalidSortKey) {
+		t.Fatalf("expected ErrInvalidSortKey, got %v", err)
+	}
+}
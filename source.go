@@ -0,0 +1,81 @@
+package goconfig
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// sourceContextLines is the number of lines shown before and after the failing line
+const sourceContextLines = 2
+
+// SourceError reports a parse failure at a specific line of a configuration file,
+// together with an excerpt of the surrounding raw input in the style of a compiler error.
+type SourceError struct {
+	// File is the name of the file that failed to parse
+	File string
+
+	// Line is the 1-based line number of the failure
+	Line int
+
+	// Column is the 1-based column of the failure, or 0 if the parser does not report one
+	Column int
+
+	// Context is the excerpt around the failing line, which is marked with ">"
+	Context string
+
+	// Err is the underlying parse error
+	Err error
+}
+
+// NewSourceError returns a SourceError for a failure at line of file, extracting the
+// context excerpt from data, the raw contents of the file
+func NewSourceError(file string, data []byte, line int, err error) *SourceError {
+	return &SourceError{
+		File:    file,
+		Line:    line,
+		Context: sourceContext(data, line),
+		Err:     err,
+	}
+}
+
+func (e *SourceError) Error() string {
+	msg := fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+	if e.Column > 0 {
+		msg = fmt.Sprintf("%s:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+	}
+
+	if e.Context == "" {
+		return msg
+	}
+
+	return msg + "\n" + e.Context
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// sourceContext renders the lines around line with line numbers, marking line with ">"
+func sourceContext(data []byte, line int) string {
+	lines := strings.Split(string(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	first := max(line-sourceContextLines, 1)
+	last := min(line+sourceContextLines, len(lines))
+	width := len(fmt.Sprint(last))
+
+	var sb strings.Builder
+	for n := first; n <= last; n++ {
+		marker := " "
+		if n == line {
+			marker = ">"
+		}
+
+		fmt.Fprintf(&sb, "%s %*d | %s\n", marker, width, n, lines[n-1])
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,50 @@
+package goconfig_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nikita-shtimenko/goconfig"
+)
+
+func TestSourceErrorContext(t *testing.T) {
+	data := []byte("one\ntwo\nthree\nfour\nfive\nsix\nseven\n")
+	cause := errors.New("unexpected token")
+
+	err := goconfig.NewSourceError("app.conf", data, 4, cause)
+
+	want := strings.Join([]string{
+		"app.conf:4: unexpected token",
+		"  2 | two",
+		"  3 | three",
+		"> 4 | four",
+		"  5 | five",
+		"  6 | six",
+	}, "\n")
+
+	if err.Error() != want {
+		t.Errorf("unexpected error message\nexpected:\n%s\ngot:\n%s", want, err.Error())
+	}
+
+	if !errors.Is(err, cause) {
+		t.Error("expected SourceError to unwrap to its cause")
+	}
+}
+
+func TestSourceErrorContextAtFileStart(t *testing.T) {
+	err := goconfig.NewSourceError("app.conf", []byte("bad\nok\n"), 1, errors.New("boom"))
+
+	if !strings.Contains(err.Error(), "> 1 | bad\n  2 | ok") {
+		t.Errorf("expected context starting at line 1, got:\n%s", err.Error())
+	}
+}
+
+func TestSourceErrorColumn(t *testing.T) {
+	err := goconfig.NewSourceError("app.conf", []byte("bad\n"), 1, errors.New("boom"))
+	err.Column = 3
+
+	if !strings.HasPrefix(err.Error(), "app.conf:1:3: boom\n") {
+		t.Errorf("expected column in error message, got:\n%s", err.Error())
+	}
+}
@@ -0,0 +1,33 @@
+package goconfig
+
+import "context"
+
+// StaticLoader returns a fixed configuration, e.g. in tests or as the defaults layer of a
+// ParallelLoader
+type StaticLoader[T any] struct {
+	cfg *T
+}
+
+// NewStaticLoader creates a loader that always returns a deep copy of cfg, so callers can
+// never mutate shared state. cfg is copied immediately; a nil cfg loads the zero configuration.
+func NewStaticLoader[T any](cfg *T) *StaticLoader[T] {
+	if cfg == nil {
+		cfg = new(T)
+	}
+
+	return &StaticLoader[T]{cfg: Clone(cfg)}
+}
+
+// Load returns a deep copy of the static configuration
+func (l *StaticLoader[T]) Load() (*T, error) {
+	return Clone(l.cfg), nil
+}
+
+// LoadContext returns a deep copy of the static configuration, or ctx.Err() if ctx is done
+func (l *StaticLoader[T]) LoadContext(ctx context.Context) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return l.Load()
+}
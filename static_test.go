@@ -0,0 +1,51 @@
+package goconfig_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+func TestStaticLoaderReturnsCopies(t *testing.T) {
+	original := &layeredConfig{Port: 8080}
+	original.Database.Host = "db.internal"
+
+	loader := goconfig.NewStaticLoader(original)
+
+	first, err := goconfig.NewConfig[layeredConfig](loader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first == original {
+		t.Fatal("expected a distinct copy, got the original pointer")
+	}
+
+	if *first != *original {
+		t.Errorf("expected %+v, got %+v", original, first)
+	}
+
+	first.Port = 9090
+	original.Database.Host = "changed"
+
+	second, err := loader.LoadContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if second.Port != 8080 || second.Database.Host != "db.internal" {
+		t.Errorf("expected the static config to be unaffected by mutations, got %+v", second)
+	}
+}
+
+func TestStaticLoaderCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := goconfig.NewStaticLoader[layeredConfig](nil).LoadContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
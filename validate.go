@@ -0,0 +1,27 @@
+package goconfig
+
+import "fmt"
+
+// Validator is implemented by configuration types that check their own invariants,
+// e.g. that a port range is not inverted
+type Validator interface {
+	Validate() error
+}
+
+// ValidateConfig runs loader like NewConfig and, if *T implements Validator, calls Validate on
+// the result, discarding the configuration. It returns nil if the configuration is valid, so it
+// suits a "config check" command run before deploying.
+func ValidateConfig[T any](loader ConfigLoader[T]) error {
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("config failed to load: %w", err)
+	}
+
+	if validator, ok := any(cfg).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("config is invalid: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package goconfig_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	goconfig "github.com/nikita-shtimenko/goconfig"
+)
+
+type portRangeConfig struct {
+	MinPort int
+	MaxPort int
+}
+
+func (c *portRangeConfig) Validate() error {
+	var errs []error
+	if c.MinPort <= 0 {
+		errs = append(errs, errors.New("MinPort must be positive"))
+	}
+
+	if c.MaxPort < c.MinPort {
+		errs = append(errs, errors.New("MaxPort must not be below MinPort"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func TestValidateConfig(t *testing.T) {
+	valid := goconfig.NewStaticLoader(&portRangeConfig{MinPort: 8000, MaxPort: 8100})
+	if err := goconfig.ValidateConfig[portRangeConfig](valid); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+
+	invalid := goconfig.NewStaticLoader(&portRangeConfig{MinPort: 0, MaxPort: -1})
+	err := goconfig.ValidateConfig[portRangeConfig](invalid)
+	if err == nil {
+		t.Fatal("expected an error for an invalid config")
+	}
+
+	for _, want := range []string{"config is invalid", "MinPort must be positive", "MaxPort must not be below MinPort"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %q", want, err)
+		}
+	}
+}
+
+func TestValidateConfigLoadFailure(t *testing.T) {
+	errSource := errors.New("source unavailable")
+	loader := &fakeLoader{
+		load: func(int) (*sampleConfig, error) {
+			return nil, errSource
+		},
+	}
+
+	if err := goconfig.ValidateConfig[sampleConfig](loader); !errors.Is(err, errSource) {
+		t.Fatalf("expected load error, got %v", err)
+	}
+}